@@ -5,9 +5,9 @@
 package ieddata
 
 import (
-	"errors"
-	"reflect"
-	"slices"
+	"context"
+	"fmt"
+	"iter"
 	"time"
 )
 
@@ -19,7 +19,9 @@ import (
 // with an initial uppercase letter necessary due to Go's implicit export rules.
 // The few exceptions are URL and CompanyURL instead of Company/(w)ebAddress,
 // Created/Modified instead of (c)reated/ModifiedDate, as well as avoiding
-// stuttering as to not use “App” prefixes.
+// stuttering as to not use “App” prefixes. Created and Modified are the dates
+// of the application, whereas VersionCreated is the creation date of the app
+// version.
 type App struct {
 	Id                    string `db:"appId"`
 	Version               string `db:"appVersion"`
@@ -37,13 +39,14 @@ type App struct {
 	AppStatus             int
 	CompanyName           string `db:"companyName"`
 	CompanyURL            string `db:"companyWebAddress"`
-	IsDeveloperAppInstall int    `db:"isDeveloperAppInstall"`
-	IsVisible             int    `db:"isVisible"`
+	IsDeveloperAppInstall Flag   `db:"isDeveloperAppInstall"`
+	IsVisible             Flag   `db:"isVisible"`
 	SortWeight            int    `db:"sortWeight"`
 	RunAsService          bool   `db:"runasservice"`
-	IsUpdatedOnPortal     int
+	IsUpdatedOnPortal     Flag
 	Created               time.Time `db:"createdDate"`
 	Modified              time.Time `db:"modifiedDate"`
+	VersionCreated        time.Time `db:"versionCreatedDate"`
 	ComposerFilepath      string    `db:"composerFilePath"`
 	RedirectType          string
 	RedirectUrl           string
@@ -52,79 +55,189 @@ type App struct {
 	ToExecuteOrder        string
 	Metadata              string
 	ServiceLabels         string
-	IsSecure              int
-	IsSwarmModeEnable     int
-	IsDebuggingEnabled    int `db:"isDebuggingEnabled"`
+	IsSecure              Flag
+	IsSwarmModeEnable     Flag
+	IsDebuggingEnabled    Flag `db:"isDebuggingEnabled"`
+}
+
+// AppsOption configures which apps Apps, AppsContext, and AppsSeq return.
+type AppsOption func(*appsOptions)
+
+type appsOptions struct {
+	activeVersions bool
+}
+
+// WithActiveVersions returns only a single entry per app, with only its active
+// version, instead of an entry for each version of an app. The active version
+// is the installed version or, if there is no installed version, the version
+// with the newest createdDate.
+//
+// Please note that the IE runtime doesn't document which versionStatus marks
+// installed versions; WithActiveVersions currently assumes the status value
+// found in the test database, but this has not been confirmed with the
+// databases of actual IE runtimes. Where this assumption doesn't hold, the
+// selection falls back to the version with the newest createdDate, without
+// any indication to the caller.
+func WithActiveVersions() AppsOption {
+	return func(o *appsOptions) {
+		o.activeVersions = true
+	}
 }
 
 // Apps returns a slice of App elements with information about the currently
 // installed apps and their versions. The information is read from the
 // application and applicationversions tables in a “platformbox.db”, so make sure
 // that the correct database has been Open'ed.
-func (db *AppEngineDB) Apps() ([]App, error) {
+//
+// By default, Apps returns an App element for each version of an app. Use
+// WithActiveVersions to get only a single App element per app with its active
+// version.
+func (db *AppEngineDB) Apps(opts ...AppsOption) ([]App, error) {
+	return db.AppsContext(context.Background(), opts...)
+}
+
+// AppsContext works like Apps, but additionally honors cancellation and
+// deadlines of the specified context while querying the database.
+func (db *AppEngineDB) AppsContext(ctx context.Context, opts ...AppsOption) ([]App, error) {
 	apps := make([]App, 0)
-	// In order to not fail when there are new fields getting added, we need to
-	// use the "unsafe" db handle here: for details, please see
-	// https://jmoiron.github.io/sqlx/#safety.
-	unsafedb := db.Unsafe()
-	rows, err := unsafedb.Queryx("SELECT * FROM application INNER JOIN applicationversions USING(appId)")
-	if err != nil {
-		return nil, err
+	for app, err := range db.AppsSeq(ctx, opts...) {
+		if err != nil {
+			return nil, err
+		}
+		apps = append(apps, app)
 	}
-	defer rows.Close()
-
-	// Work around cznic/sqlite not fully supporting sqlx for the moment. For
-	// this, we need to map the query result column to their App struct fields.
-	// In particular, we map column indices (instead of names) to field indices.
-	// A field index < 0 indicates that the column does not match any App struct
-	// field.
-	cols, err := rows.Columns()
-	if err != nil {
-		return nil, err
+	return apps, nil
+}
+
+// AppsSeq returns an iterator over the currently installed apps and their
+// versions, streaming the apps from the database instead of first reading them
+// all into a slice, as Apps does. When the iteration stops early, AppsSeq
+// immediately releases the underlying query result.
+//
+// On failure, the iterator yields a single error (together with a zero App) and
+// then stops. Each iteration queries the database anew.
+func (db *AppEngineDB) AppsSeq(ctx context.Context, opts ...AppsOption) iter.Seq2[App, error] {
+	o := appsOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return db.appsSeq(ctx, o, "")
+}
+
+// appsSeq returns an iterator over the installed apps and their versions,
+// optionally restricted by the specified SQL “WHERE” condition with its
+// arguments.
+func (db *AppEngineDB) appsSeq(ctx context.Context, o appsOptions, where string, args ...any) iter.Seq2[App, error] {
+	// As both tables have createdDate and modifiedDate columns, the App fields
+	// Created and Modified get the application's dates, so we need to
+	// additionally query the version's creation date under a different name.
+	query := "SELECT *, applicationversions.createdDate AS versionCreatedDate" +
+		" FROM application INNER JOIN applicationversions USING(appId)"
+	if where != "" {
+		query += " WHERE " + where
 	}
-	appT := reflect.TypeOf(App{})
-	columnFieldIndices := make([]int, len(cols))
-	for columnIndex := range columnFieldIndices {
-		columnFieldIndices[columnIndex] = -1 // no corresponding field
+	if o.activeVersions {
+		// Group the versions of the same app together.
+		query += " ORDER BY appId"
 	}
-	for fieldIdx := range appT.NumField() {
-		columnName := appT.Field(fieldIdx).Tag.Get("db")
-		if columnName == "" {
-			columnName = FirstLower(appT.Field(fieldIdx).Name)
-		}
-		columnIdx := slices.Index(cols, columnName)
-		if columnIdx < 0 {
-			continue
+	return func(yield func(App, error) bool) {
+		rows, err := db.DB.QueryContext(ctx, query, args...)
+		if err != nil {
+			yield(App{}, wrongDatabaseError(err))
+			return
 		}
-		columnFieldIndices[columnIdx] = fieldIdx
-	}
-
-	for rows.Next() {
-		var app App
-		/*
-			if err := rows.StructScan(&app); err != nil {
-				return nil, err
+		var active *App // active version of the current app, if any.
+		for app, err := range scanRows[App](rows) {
+			if err == nil && app.Id == "" {
+				err = fmt.Errorf("%w: empty IE App identifier: did you open the correct data base?",
+					ErrWrongDatabase)
+			}
+			if err != nil {
+				yield(App{}, err)
+				return
 			}
-		*/
-		appV := reflect.ValueOf(&app).Elem()
-		values := make([]any, len(cols))
-		for columnIdx := range values {
-			fieldIndex := columnFieldIndices[columnIdx]
-			if fieldIndex < 0 {
-				values[columnIdx] = new(any)
+			if !o.activeVersions {
+				if !yield(app, nil) {
+					return
+				}
 				continue
 			}
-			values[columnIdx] = appV.Field(fieldIndex).Addr().Interface()
+			if active != nil && active.Id == app.Id {
+				if isPreferredVersion(&app, active) {
+					*active = app
+				}
+				continue
+			}
+			if active != nil && !yield(*active, nil) {
+				return
+			}
+			active = &app
 		}
-		if err := rows.Scan(values...); err != nil {
-			return nil, err
+		if active != nil {
+			yield(*active, nil)
 		}
+	}
+}
+
+// AppByID returns the installed app with the specified app ID and its active
+// version, or an AppNotFoundError if there is no such app.
+func (db *AppEngineDB) AppByID(id string) (*App, error) {
+	return db.AppByIDContext(context.Background(), id)
+}
+
+// AppByIDContext works like AppByID, but additionally honors cancellation and
+// deadlines of the specified context while querying the database.
+func (db *AppEngineDB) AppByIDContext(ctx context.Context, id string) (*App, error) {
+	return db.appBy(ctx, "appId", id)
+}
+
+// AppByRepositoryName returns the installed app with the specified (unique)
+// repository name and its active version, or an AppNotFoundError if there is no
+// such app.
+func (db *AppEngineDB) AppByRepositoryName(name string) (*App, error) {
+	return db.AppByRepositoryNameContext(context.Background(), name)
+}
+
+// AppByRepositoryNameContext works like AppByRepositoryName, but additionally
+// honors cancellation and deadlines of the specified context while querying the
+// database.
+func (db *AppEngineDB) AppByRepositoryNameContext(ctx context.Context, name string) (*App, error) {
+	return db.appBy(ctx, "repositoryName", name)
+}
+
+// AppsByTitle returns the installed apps with the specified title and their
+// active versions; as titles aren't unique, there might be multiple apps with
+// the same title. If there is no app with the title, AppsByTitle returns an
+// AppNotFoundError.
+func (db *AppEngineDB) AppsByTitle(title string) ([]App, error) {
+	return db.AppsByTitleContext(context.Background(), title)
+}
 
-		if app.Id == "" {
-			return nil, errors.New("empty IE App identifier: did you open the correct data base?")
+// AppsByTitleContext works like AppsByTitle, but additionally honors
+// cancellation and deadlines of the specified context while querying the
+// database.
+func (db *AppEngineDB) AppsByTitleContext(ctx context.Context, title string) ([]App, error) {
+	apps := make([]App, 0)
+	for app, err := range db.appsSeq(ctx, appsOptions{activeVersions: true}, "title=?", title) {
+		if err != nil {
+			return nil, err
 		}
 		apps = append(apps, app)
 	}
-
+	if len(apps) == 0 {
+		return nil, &AppNotFoundError{Column: "title", Value: title}
+	}
 	return apps, nil
 }
+
+// appBy returns the first installed app with the specified value in the
+// specified column and its active version, or an AppNotFoundError.
+func (db *AppEngineDB) appBy(ctx context.Context, column string, value string) (*App, error) {
+	for app, err := range db.appsSeq(ctx, appsOptions{activeVersions: true}, column+"=?", value) {
+		if err != nil {
+			return nil, err
+		}
+		return &app, nil
+	}
+	return nil, &AppNotFoundError{Column: column, Value: value}
+}
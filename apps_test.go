@@ -5,6 +5,8 @@
 package ieddata
 
 import (
+	"context"
+	"errors"
 	"os"
 	"path"
 	"time"
@@ -30,7 +32,7 @@ var _ = Describe("IED app engine installed apps", func() {
 		// Use a local test database, so we don't need to rely on an (fake) edge
 		// core running.
 		cwd := Successful(os.Getwd())
-		db := Successful(open(path.Join(cwd, "tests/sqlite-alpine-appengine-db/test-apps-and-device.db"), model.PIDType(os.Getpid())))
+		db := Successful(open(context.Background(), path.Join(cwd, "tests/sqlite-alpine-appengine-db/test-apps-and-device.db"), model.PIDType(os.Getpid())))
 		defer db.Close()
 
 		apps := Successful(db.Apps())
@@ -45,7 +47,68 @@ var _ = Describe("IED app engine installed apps", func() {
 			HaveField("Id", Not(BeZero())),
 			HaveField("IconPath", Not(BeZero())),
 		)))
-		Expect(apps).To(ContainElement(HaveField("IsDebuggingEnabled", 1)))
+		Expect(apps).To(ContainElement(HaveField("IsDebuggingEnabled", Flag(true))))
+	})
+
+	It("streams installed app information", func(ctx context.Context) {
+		db := Successful(OpenFileContext(ctx, "tests/sqlite-alpine-appengine-db/test-apps-and-device.db"))
+		defer db.Close()
+
+		var titles []string
+		for app, err := range db.AppsSeq(ctx) {
+			Expect(err).NotTo(HaveOccurred())
+			titles = append(titles, app.Title)
+		}
+		Expect(titles).To(ConsistOf("AppA", "AppB", "AppC", "AppD"))
+
+		count := 0
+		for range db.AppsSeq(ctx) {
+			count++
+			break
+		}
+		Expect(count).To(Equal(1))
+		// Stopping early must have released the query, otherwise closing
+		// the database would block.
+		Expect(db.Close()).To(Succeed())
+	})
+
+	It("streams a single error for the wrong database", func(ctx context.Context) {
+		db := Successful(OpenFileContext(ctx, "tests/sqlite-alpine-appengine-db/test-apps-and-device.db"))
+		defer db.Close()
+		Expect(db.Exec("DROP TABLE applicationversions")).Error().NotTo(HaveOccurred())
+
+		var errs []error
+		for _, err := range db.AppsSeq(ctx) {
+			errs = append(errs, err)
+		}
+		Expect(errs).To(ConsistOf(MatchError(ErrWrongDatabase)))
+	})
+
+	It("looks up individual apps", func(ctx context.Context) {
+		db := Successful(OpenFileContext(ctx, "tests/sqlite-alpine-appengine-db/test-apps-and-device.db"))
+		defer db.Close()
+
+		Expect(db.AppByID("195ff5e2e15a149ca5eb7c59d3857cc5")).To(HaveField("Title", "AppA"))
+		Expect(db.AppByRepositoryName("ccc")).To(HaveField("Id", "1842f53281412f9c657c7765494ff80e"))
+		Expect(db.AppsByTitle("AppB")).To(HaveExactElements(HaveField("RepositoryName", "bbb")))
+
+		var notFound *AppNotFoundError
+		_, err := db.AppByIDContext(ctx, "foobar")
+		Expect(err).To(MatchError(ErrAppNotFound))
+		Expect(errors.As(err, &notFound)).To(BeTrue())
+		Expect(notFound).To(Equal(&AppNotFoundError{Column: "appId", Value: "foobar"}))
+		Expect(db.AppByRepositoryNameContext(ctx, "foobar")).Error().To(MatchError(ErrAppNotFound))
+		Expect(db.AppsByTitleContext(ctx, "foobar")).Error().To(MatchError(ErrAppNotFound))
+	})
+
+	It("honors a cancelled context", func(ctx context.Context) {
+		cwd := Successful(os.Getwd())
+		db := Successful(open(ctx, path.Join(cwd, "tests/sqlite-alpine-appengine-db/test-apps-and-device.db"), model.PIDType(os.Getpid())))
+		defer db.Close()
+
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+		Expect(db.AppsContext(ctx)).Error().To(MatchError(context.Canceled))
 	})
 
 })
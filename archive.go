@@ -0,0 +1,295 @@
+// (c) Siemens AG 2026
+//
+// SPDX-License-Identifier: MIT
+
+package ieddata
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+)
+
+var (
+	zipMagic  = []byte("PK\x03\x04")
+	gzipMagic = []byte{0x1f, 0x8b}
+)
+
+// DefaultMaxArchiveExtractSize is the default maximum total size of the
+// database and its side files extracted from an archive.
+const DefaultMaxArchiveExtractSize = 4 << 30
+
+// OpenArchive opens the specified app engine database, such as
+// “platformbox.db”, from inside an IED backup or support archive. The archive
+// can be a tar, gzip'ed tar, or zip file. OpenArchive looks for an archive
+// member with a path ending in the app engine database location, that is,
+// “data/app_engine/db/platformbox.db”, optionally preceded by further leading
+// directories. If present, the database's write-ahead log and rollback journal
+// files from the same archive directory get extracted as well. If the archive
+// contains multiple database members, the first one wins.
+//
+// The database name is sanitized in the same way as Open does. Only the
+// WithDBBaseDir, WithTempDir, and WithMaxArchiveExtractSize options apply to
+// OpenArchive.
+//
+// The database is extracted into a temporary copy that gets removed when
+// closing the database again. If the database and its side files together
+// exceed the maximum extraction size, OpenArchive returns an error wrapping
+// ErrDatabaseTooLarge. As there is no original database file,
+// refreshing the database never changes it.
+func OpenArchive(archivePath string, dbname string, opts ...Option) (*AppEngineDB, error) {
+	return OpenArchiveContext(context.Background(), archivePath, dbname, opts...)
+}
+
+// OpenArchiveContext works like OpenArchive, but additionally honors
+// cancellation and deadlines of the specified context while extracting the
+// app engine database.
+func OpenArchiveContext(ctx context.Context, archivePath string, dbname string, opts ...Option) (*AppEngineDB, error) {
+	o := newOptions(opts)
+	member := strings.TrimPrefix(path.Join(o.dbBaseDir, sanitize(dbname)), "/")
+
+	f, err := os.Open(archivePath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			err = fmt.Errorf("%w: %w", ErrDatabaseNotFound, err)
+		}
+		return nil, fmt.Errorf("unable to open archive, reason: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	snap, err := newSnapshot(o.tempDir, path.Base(member))
+	if err != nil {
+		return nil, fmt.Errorf("unable to open database, reason: %w", err)
+	}
+	found, err := snap.extractArchive(ctx, f, member, o.maxExtractSize)
+	if err != nil {
+		snap.remove()
+		return nil, fmt.Errorf("unable to extract database from archive %q, reason: %w",
+			archivePath, err)
+	}
+	if !found {
+		snap.remove()
+		return nil, fmt.Errorf("%w: no %q in archive %q", ErrDatabaseNotFound, member, archivePath)
+	}
+	dbf, err := os.Open(snap.dbpath)
+	if err == nil {
+		err = checkHeader(dbf)
+		_ = dbf.Close()
+	}
+	if err != nil {
+		snap.remove()
+		return nil, fmt.Errorf("unable to open database, reason: %w", err)
+	}
+	if err := snap.verify(ctx); err != nil {
+		snap.remove()
+		return nil, err
+	}
+	db, snaps := newSnapshotDB(snap)
+	return &AppEngineDB{
+		DB:      db,
+		snaps:   snaps,
+		tempDir: o.tempDir,
+	}, nil
+}
+
+// extractArchive extracts the specified database member as well as its side
+// files from the archive into the snapshot, returning true if the database
+// member was found. The first matching database member wins, and only side
+// files in the same archive directory as this database member get extracted.
+// The extracted files must not exceed maxSize in total.
+func (s *snapshot) extractArchive(ctx context.Context, f *os.File, member string, maxSize int64) (bool, error) {
+	magic := make([]byte, len(zipMagic))
+	n, err := f.ReadAt(magic, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, err
+	}
+	magic = magic[:n]
+	switch {
+	case bytes.HasPrefix(magic, zipMagic):
+		info, err := f.Stat()
+		if err != nil {
+			return false, err
+		}
+		return s.extractZip(ctx, f, info.Size(), member, maxSize)
+	case bytes.HasPrefix(magic, gzipMagic):
+		return s.extractTar(ctx, func() (io.ReadCloser, error) {
+			if _, err := f.Seek(0, io.SeekStart); err != nil {
+				return nil, err
+			}
+			return gzip.NewReader(f)
+		}, member, maxSize)
+	default:
+		return s.extractTar(ctx, func() (io.ReadCloser, error) {
+			if _, err := f.Seek(0, io.SeekStart); err != nil {
+				return nil, err
+			}
+			return io.NopCloser(f), nil
+		}, member, maxSize)
+	}
+}
+
+// extractTar extracts the database member and its side files from a tar
+// stream. As side files might precede the database member, extractTar first
+// locates the database member and then extracts it together with its side
+// files in a second pass, opening the tar stream anew for each pass.
+func (s *snapshot) extractTar(ctx context.Context, open func() (io.ReadCloser, error), member string, maxSize int64) (bool, error) {
+	dbEntry := ""
+	err := walkTar(ctx, open, func(name string, _ io.Reader) (bool, error) {
+		if isDBMember(name, member) {
+			dbEntry = name
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil || dbEntry == "" {
+		return false, err
+	}
+	extracted := map[string]struct{}{}
+	err = walkTar(ctx, open, func(name string, r io.Reader) (bool, error) {
+		target := s.memberTarget(name, dbEntry)
+		if target == "" {
+			return true, nil
+		}
+		if _, ok := extracted[target]; ok {
+			return true, nil
+		}
+		n, err := writeFile(ctx, target, r, maxSize)
+		if err != nil {
+			return false, err
+		}
+		maxSize -= n
+		extracted[target] = struct{}{}
+		return true, nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// walkTar calls fn with the cleaned name and contents of each regular file in
+// the tar stream, until fn returns false or an error.
+func walkTar(ctx context.Context, open func() (io.ReadCloser, error), fn func(name string, r io.Reader) (bool, error)) error {
+	r, err := open()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = r.Close() }()
+	tr := tar.NewReader(r)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		cont, err := fn(archiveEntryName(hdr.Name), tr)
+		if err != nil || !cont {
+			return err
+		}
+	}
+}
+
+// extractZip extracts the database member and its side files from a zip
+// archive.
+func (s *snapshot) extractZip(ctx context.Context, r io.ReaderAt, size int64, member string, maxSize int64) (bool, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return false, err
+	}
+	dbEntry := ""
+	for _, zf := range zr.File {
+		if name := archiveEntryName(zf.Name); zf.Mode().IsRegular() && isDBMember(name, member) {
+			dbEntry = name
+			break
+		}
+	}
+	if dbEntry == "" {
+		return false, nil
+	}
+	extracted := map[string]struct{}{}
+	for _, zf := range zr.File {
+		if !zf.Mode().IsRegular() {
+			continue
+		}
+		target := s.memberTarget(archiveEntryName(zf.Name), dbEntry)
+		if target == "" {
+			continue
+		}
+		if _, ok := extracted[target]; ok {
+			continue
+		}
+		rc, err := zf.Open()
+		if err != nil {
+			return false, err
+		}
+		n, err := writeFile(ctx, target, rc, maxSize)
+		_ = rc.Close()
+		if err != nil {
+			return false, err
+		}
+		maxSize -= n
+		extracted[target] = struct{}{}
+	}
+	return true, nil
+}
+
+// archiveEntryName returns the cleaned name of an archive entry, without any
+// leading slash.
+func archiveEntryName(name string) string {
+	return strings.TrimPrefix(path.Clean("/"+name), "/")
+}
+
+// isDBMember returns true if the cleaned archive entry name is the specified
+// database member, optionally preceded by further leading directories.
+func isDBMember(name string, member string) bool {
+	return name == member || strings.HasSuffix(name, "/"+member)
+}
+
+// memberTarget returns the snapshot file path to extract the archive entry
+// with the specified cleaned name to, if the entry is either the specified
+// database entry or one of its side files in the same archive directory;
+// otherwise, it returns "".
+func (s *snapshot) memberTarget(name string, dbEntry string) string {
+	for _, suffix := range append([]string{""}, sideFileSuffixes...) {
+		if name == dbEntry+suffix {
+			return s.dbpath + suffix
+		}
+	}
+	return ""
+}
+
+// writeFile writes the contents read from r into the dst file, creating or
+// truncating it as necessary, and returns the number of bytes written. It
+// returns an error wrapping ErrDatabaseTooLarge if there are more than maxSize
+// bytes to read.
+func writeFile(ctx context.Context, dst string, r io.Reader, maxSize int64) (int64, error) {
+	dstf, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return 0, err
+	}
+	n, err := io.Copy(dstf, io.LimitReader(&contextReader{ctx: ctx, r: r}, max(maxSize, 0)+1))
+	if err == nil && n > maxSize {
+		err = fmt.Errorf("%w: exceeding %d bytes", ErrDatabaseTooLarge, maxSize)
+	}
+	if closeErr := dstf.Close(); err == nil {
+		err = closeErr
+	}
+	return n, err
+}
@@ -0,0 +1,177 @@
+// (c) Siemens AG 2026
+//
+// SPDX-License-Identifier: MIT
+
+package ieddata
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/thediveo/success"
+)
+
+const testArchiveDBMember = "backup/data/app_engine/db/platformbox.db"
+
+// archiveFile describes a file to be stored in a test archive.
+type archiveFile struct {
+	name     string
+	contents []byte
+}
+
+// testArchiveFiles returns the files to store in a test archive: some
+// unrelated files, as well as the test apps and device database.
+func testArchiveFiles() []archiveFile {
+	GinkgoHelper()
+	return []archiveFile{
+		{name: "backup/README", contents: []byte("not a database")},
+		{name: "backup/data/app_engine/db/other.db", contents: []byte("not a database either")},
+		{name: testArchiveDBMember,
+			contents: Successful(os.ReadFile("tests/sqlite-alpine-appengine-db/test-apps-and-device.db"))},
+	}
+}
+
+// writeTar writes the files as a tar archive to w.
+func writeTar(w io.Writer, files []archiveFile) {
+	GinkgoHelper()
+	tw := tar.NewWriter(w)
+	for _, file := range files {
+		Expect(tw.WriteHeader(&tar.Header{
+			Name:     file.name,
+			Typeflag: tar.TypeReg,
+			Mode:     0600,
+			Size:     int64(len(file.contents)),
+		})).To(Succeed())
+		Expect(tw.Write(file.contents)).Error().NotTo(HaveOccurred())
+	}
+	Expect(tw.Close()).To(Succeed())
+}
+
+// newTestArchive creates a test archive of the specified kind (“tar”,
+// “tar.gz”, or “zip”) with the specified files, returning the archive's path.
+func newTestArchive(kind string, files []archiveFile) string {
+	GinkgoHelper()
+	archivePath := filepath.Join(GinkgoT().TempDir(), "backup."+kind)
+	f := Successful(os.Create(archivePath))
+	defer func() { Expect(f.Close()).To(Succeed()) }()
+	switch kind {
+	case "tar":
+		writeTar(f, files)
+	case "tar.gz":
+		gz := gzip.NewWriter(f)
+		writeTar(gz, files)
+		Expect(gz.Close()).To(Succeed())
+	case "zip":
+		zw := zip.NewWriter(f)
+		for _, file := range files {
+			w := Successful(zw.Create(file.name))
+			Expect(w.Write(file.contents)).Error().NotTo(HaveOccurred())
+		}
+		Expect(zw.Close()).To(Succeed())
+	default:
+		Fail("unsupported test archive kind " + kind)
+	}
+	return archivePath
+}
+
+var _ = Describe("IED backup and support archives", func() {
+
+	DescribeTable("opens the app engine database from inside an archive",
+		func(ctx context.Context, kind string) {
+			tempDir := GinkgoT().TempDir()
+			db := Successful(OpenArchiveContext(ctx,
+				newTestArchive(kind, testArchiveFiles()), PlatformBoxDb,
+				WithTempDir(tempDir)))
+			snapDir := db.snaps.snapshot().dir
+			Expect(snapDir).To(HavePrefix(tempDir))
+			Expect(db.AppsContext(ctx)).To(HaveLen(4))
+			Expect(db.Refresh(ctx)).To(BeFalse())
+			Expect(db.Close()).To(Succeed())
+			Expect(snapDir).NotTo(BeADirectory())
+		},
+		Entry("tar", "tar"),
+		Entry("gzip'ed tar", "tar.gz"),
+		Entry("zip", "zip"),
+	)
+
+	DescribeTable("extracts only the side files belonging to the database",
+		func(ctx context.Context, kind string) {
+			const otherDBMember = "other/data/app_engine/db/platformbox.db"
+			files := slices.Concat(
+				[]archiveFile{{name: otherDBMember + "-wal", contents: []byte("not a WAL")}},
+				testArchiveFiles(),
+				[]archiveFile{{name: otherDBMember, contents: []byte("not a database")}})
+			archivePath := newTestArchive(kind, files)
+
+			snap := Successful(newSnapshot(GinkgoT().TempDir(), PlatformBoxDb))
+			defer snap.remove()
+			f := Successful(os.Open(archivePath))
+			defer func() { _ = f.Close() }()
+			Expect(snap.extractArchive(ctx, f,
+				strings.TrimPrefix(path.Join(dbBaseDir, PlatformBoxDb), "/"),
+				DefaultMaxArchiveExtractSize)).To(BeTrue())
+			Expect(snap.dbpath + "-wal").NotTo(BeAnExistingFile())
+
+			db := Successful(OpenArchiveContext(ctx, archivePath, PlatformBoxDb))
+			defer func() { _ = db.Close() }()
+			Expect(db.AppsContext(ctx)).To(HaveLen(4))
+		},
+		Entry("tar", "tar"),
+		Entry("gzip'ed tar", "tar.gz"),
+		Entry("zip", "zip"),
+	)
+
+	It("reports a missing database", func() {
+		files := testArchiveFiles()
+		archivePath := newTestArchive("tar", files[:2])
+		Expect(OpenArchive(archivePath, PlatformBoxDb)).Error().To(MatchError(ErrDatabaseNotFound))
+		Expect(OpenArchive(archivePath+".missing", PlatformBoxDb)).Error().To(MatchError(ErrDatabaseNotFound))
+	})
+
+	DescribeTable("limits the size of the extracted database",
+		func(ctx context.Context, kind string) {
+			files := testArchiveFiles()
+			dbSize := int64(len(files[2].contents))
+			files = append(files, archiveFile{name: testArchiveDBMember + "-wal", contents: []byte("foobar")})
+			tempDir := GinkgoT().TempDir()
+			Expect(OpenArchiveContext(ctx, newTestArchive(kind, files), PlatformBoxDb,
+				WithTempDir(tempDir), WithMaxArchiveExtractSize(dbSize-1))).Error().
+				To(MatchError(ErrDatabaseTooLarge))
+			Expect(OpenArchiveContext(ctx, newTestArchive(kind, files), PlatformBoxDb,
+				WithTempDir(tempDir), WithMaxArchiveExtractSize(dbSize+5))).Error().
+				To(MatchError(ErrDatabaseTooLarge))
+			Expect(os.ReadDir(tempDir)).To(BeEmpty())
+		},
+		Entry("gzip'ed tar", "tar.gz"),
+		Entry("zip", "zip"),
+	)
+
+	It("rejects non-database members", func() {
+		archivePath := newTestArchive("zip", testArchiveFiles())
+		Expect(OpenArchive(archivePath, "other.db")).Error().To(MatchError(ErrNotADatabase))
+	})
+
+	It("rejects corrupt archives", func() {
+		archivePath := filepath.Join(GinkgoT().TempDir(), "backup.tar.gz")
+		Expect(os.WriteFile(archivePath, []byte{0x1f, 0x8b, 0x00}, 0600)).To(Succeed())
+		Expect(OpenArchive(archivePath, PlatformBoxDb)).Error().To(HaveOccurred())
+	})
+
+	It("honors cancellation", func(ctx context.Context) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+		Expect(OpenArchiveContext(ctx, newTestArchive("tar", testArchiveFiles()), PlatformBoxDb)).
+			Error().To(MatchError(context.Canceled))
+	})
+
+})
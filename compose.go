@@ -0,0 +1,129 @@
+// (c) Siemens AG 2026
+//
+// SPDX-License-Identifier: MIT
+
+package ieddata
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path"
+	"slices"
+
+	"github.com/thediveo/procfsroot"
+	"gopkg.in/yaml.v3"
+)
+
+// MaxComposeFileSize is the maximum size in bytes of the compose files read by
+// ComposeFile.
+const MaxComposeFileSize = 1 << 20
+
+// ComposeFile is the compose project file of an installed app, as deployed by
+// the IE runtime.
+type ComposeFile struct {
+	Path     string           // path of the compose file inside the IE runtime container.
+	Raw      []byte           // unparsed YAML contents.
+	Services []ComposeService // services, sorted by name.
+	Images   []string         // unique images used by the services, sorted.
+	Networks []string         // names of the networks defined by the project, sorted.
+	Volumes  []string         // names of the volumes defined by the project, sorted.
+}
+
+// ComposeService describes a single service of a compose project.
+type ComposeService struct {
+	Name          string
+	Image         string
+	ContainerName string // explicit container name, if any.
+}
+
+// composeProject is the subset of a compose project file we're interested in.
+type composeProject struct {
+	Services map[string]struct {
+		Image         string `yaml:"image"`
+		ContainerName string `yaml:"container_name"`
+	} `yaml:"services"`
+	Networks map[string]any `yaml:"networks"`
+	Volumes  map[string]any `yaml:"volumes"`
+}
+
+// ComposeFile returns the compose project file of the specified app (version),
+// as referenced by its ComposerFilepath. ComposeFile reads the compose file
+// from inside the IE runtime container, so it only works for databases opened
+// using Open, OpenContext, or OpenInPID, but not for databases opened using
+// OpenFile or OpenArchive; it then returns an error wrapping
+// ErrNoRuntimeContainer. ComposeFile returns an error wrapping
+// ErrComposeFileTooLarge if the compose file exceeds MaxComposeFileSize.
+func (db *AppEngineDB) ComposeFile(app App) (*ComposeFile, error) {
+	return db.ComposeFileContext(context.Background(), app)
+}
+
+// ComposeFileContext works like ComposeFile, but additionally honors
+// cancellation and deadlines of the specified context while reading the
+// compose file.
+func (db *AppEngineDB) ComposeFileContext(ctx context.Context, app App) (*ComposeFile, error) {
+	if db.pid == 0 {
+		return nil, fmt.Errorf("%w: database not opened from inside an IE runtime container",
+			ErrNoRuntimeContainer)
+	}
+	if app.ComposerFilepath == "" {
+		return nil, fmt.Errorf("app %q lacks a compose file path", app.Id)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	rootpath := fmt.Sprintf("/proc/%d/root", db.pid)
+	composePath, err := procfsroot.EvalSymlinks(app.ComposerFilepath, rootpath, procfsroot.EvalFullPath)
+	if err != nil {
+		return nil, fmt.Errorf("cannot determine full compose file path, reason: %w", err)
+	}
+	f, err := os.Open(path.Join(rootpath, composePath))
+	if err != nil {
+		return nil, fmt.Errorf("cannot read compose file, reason: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	raw, err := io.ReadAll(io.LimitReader(&contextReader{ctx: ctx, r: f}, MaxComposeFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("cannot read compose file, reason: %w", err)
+	}
+	if len(raw) > MaxComposeFileSize {
+		return nil, fmt.Errorf("%w: compose file %q exceeds %d bytes",
+			ErrComposeFileTooLarge, app.ComposerFilepath, MaxComposeFileSize)
+	}
+	composeFile, err := parseComposeFile(raw)
+	if err != nil {
+		return nil, err
+	}
+	composeFile.Path = app.ComposerFilepath
+	return composeFile, nil
+}
+
+// parseComposeFile parses the specified compose project YAML.
+func parseComposeFile(raw []byte) (*ComposeFile, error) {
+	var project composeProject
+	if err := yaml.Unmarshal(raw, &project); err != nil {
+		return nil, fmt.Errorf("%w: invalid compose file, reason: %w", ErrMalformedValue, err)
+	}
+	composeFile := &ComposeFile{
+		Raw:      raw,
+		Services: make([]ComposeService, 0, len(project.Services)),
+		Images:   []string{},
+		Networks: slices.Sorted(maps.Keys(project.Networks)),
+		Volumes:  slices.Sorted(maps.Keys(project.Volumes)),
+	}
+	for _, name := range slices.Sorted(maps.Keys(project.Services)) {
+		service := project.Services[name]
+		composeFile.Services = append(composeFile.Services, ComposeService{
+			Name:          name,
+			Image:         service.Image,
+			ContainerName: service.ContainerName,
+		})
+		if service.Image != "" && !slices.Contains(composeFile.Images, service.Image) {
+			composeFile.Images = append(composeFile.Images, service.Image)
+		}
+	}
+	slices.Sort(composeFile.Images)
+	return composeFile, nil
+}
@@ -0,0 +1,99 @@
+// (c) Siemens AG 2026
+//
+// SPDX-License-Identifier: MIT
+
+package ieddata
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/thediveo/lxkns/model"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/thediveo/success"
+)
+
+const testComposeYAML = `services:
+  web:
+    image: nginx:latest
+    container_name: acme-web
+    networks: [proxy-redirect]
+    volumes:
+      - data:/data
+  db:
+    image: postgres:16
+  worker:
+    image: nginx:latest
+networks:
+  proxy-redirect:
+    external: true
+volumes:
+  data:
+`
+
+var _ = Describe("app compose files", func() {
+
+	var db *AppEngineDB
+
+	BeforeEach(func(ctx context.Context) {
+		cwd := Successful(os.Getwd())
+		db = Successful(open(ctx,
+			filepath.Join(cwd, "tests/sqlite-alpine-appengine-db/test-apps-and-device.db"),
+			model.PIDType(os.Getpid())))
+		DeferCleanup(func() { _ = db.Close() })
+	})
+
+	It("reads and parses an app's compose file", func() {
+		composePath := filepath.Join(GinkgoT().TempDir(), "docker-compose.yml")
+		Expect(os.WriteFile(composePath, []byte(testComposeYAML), 0600)).To(Succeed())
+
+		composeFile := Successful(db.ComposeFile(App{Id: "acme", ComposerFilepath: composePath}))
+		Expect(composeFile.Path).To(Equal(composePath))
+		Expect(string(composeFile.Raw)).To(Equal(testComposeYAML))
+		Expect(composeFile.Services).To(HaveExactElements(
+			ComposeService{Name: "db", Image: "postgres:16"},
+			ComposeService{Name: "web", Image: "nginx:latest", ContainerName: "acme-web"},
+			ComposeService{Name: "worker", Image: "nginx:latest"},
+		))
+		Expect(composeFile.Images).To(HaveExactElements("nginx:latest", "postgres:16"))
+		Expect(composeFile.Networks).To(HaveExactElements("proxy-redirect"))
+		Expect(composeFile.Volumes).To(HaveExactElements("data"))
+	})
+
+	It("reports missing and malformed compose files", func() {
+		Expect(db.ComposeFile(App{Id: "acme"})).Error().To(MatchError(ContainSubstring("lacks a compose file path")))
+
+		composePath := filepath.Join(GinkgoT().TempDir(), "docker-compose.yml")
+		Expect(db.ComposeFile(App{ComposerFilepath: composePath})).Error().To(MatchError(fs.ErrNotExist))
+
+		Expect(os.WriteFile(composePath, []byte("services: [foo"), 0600)).To(Succeed())
+		Expect(db.ComposeFile(App{ComposerFilepath: composePath})).Error().To(MatchError(ErrMalformedValue))
+	})
+
+	It("reports oversized compose files", func() {
+		composePath := filepath.Join(GinkgoT().TempDir(), "docker-compose.yml")
+		Expect(os.WriteFile(composePath, make([]byte, MaxComposeFileSize+1), 0600)).To(Succeed())
+		Expect(db.ComposeFile(App{ComposerFilepath: composePath})).Error().To(MatchError(ErrComposeFileTooLarge))
+	})
+
+	It("doesn't read compose files when the context is already done", func(ctx context.Context) {
+		composePath := filepath.Join(GinkgoT().TempDir(), "docker-compose.yml")
+		Expect(os.WriteFile(composePath, []byte(testComposeYAML), 0600)).To(Succeed())
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+		Expect(db.ComposeFileContext(ctx, App{ComposerFilepath: composePath})).Error().
+			To(MatchError(context.Canceled))
+	})
+
+	It("needs an IE runtime container", func(ctx context.Context) {
+		db := Successful(OpenFileContext(ctx, "tests/sqlite-alpine-appengine-db/test-apps-and-device.db"))
+		defer func() { _ = db.Close() }()
+		app := Successful(db.AppByRepositoryNameContext(ctx, "aaa"))
+		Expect(db.ComposeFile(*app)).Error().To(MatchError(ErrNoRuntimeContainer))
+	})
+
+})
@@ -0,0 +1,253 @@
+// (c) Siemens AG 2026
+//
+// SPDX-License-Identifier: MIT
+
+package ieddata
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"sync"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// sqliteDriver returns the registered SQLite database driver.
+var sqliteDriver = sync.OnceValue(func() driver.Driver {
+	// sql.Open only validates its arguments without connecting to anything.
+	db, err := sql.Open(dbDriverName, "")
+	if err != nil {
+		panic(err)
+	}
+	defer func() { _ = db.Close() }()
+	return db.Driver()
+})
+
+// snapshotConnector opens database connections to the current snapshot of an
+// app engine database. Refresh thus can switch snapshots without having to
+// replace the sqlx.DB used by an AppEngineDB, and thus without pulling the rug
+// from under queries still in progress.
+//
+// Connections to a previous snapshot are discarded as soon as they are
+// returned to the connection pool, or when the pool is about to reuse them.
+// A previous snapshot gets removed after its last connection has been closed.
+type snapshotConnector struct {
+	mu      sync.Mutex
+	current *snapshotRef // snapshot to open new connections to; nil when closed.
+}
+
+// snapshotRef tracks the connections opened to a particular snapshot.
+type snapshotRef struct {
+	snap    *snapshot
+	conns   int  // number of open connections to this snapshot.
+	retired bool // snapshot has been replaced or its database closed.
+}
+
+var _ driver.Connector = (*snapshotConnector)(nil)
+
+// newSnapshotDB returns a new sqlx.DB for the specified snapshot, together
+// with the connector to later switch snapshots. The returned database uses the
+// FirstLower mapper.
+func newSnapshotDB(snap *snapshot) (*sqlx.DB, *snapshotConnector) {
+	c := &snapshotConnector{current: &snapshotRef{snap: snap}}
+	db := sqlx.NewDb(sql.OpenDB(c), dbDriverName)
+	// Install a default mapper function that preserves camelCase, with the
+	// first letter always being lowercase.
+	db.MapperFunc(FirstLower)
+	return db, c
+}
+
+// Connect opens a new connection to the current snapshot.
+func (c *snapshotConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	c.mu.Lock()
+	ref := c.current
+	if ref == nil {
+		c.mu.Unlock()
+		return nil, ErrClosed
+	}
+	ref.conns++
+	c.mu.Unlock()
+
+	conn, err := c.Driver().Open(ref.snap.dbpath)
+	if err != nil {
+		c.release(ref)
+		return nil, err
+	}
+	return &snapshotConn{Conn: conn, connector: c, ref: ref}, nil
+}
+
+// Driver returns the underlying SQLite driver.
+func (c *snapshotConnector) Driver() driver.Driver {
+	return sqliteDriver()
+}
+
+// snapshot returns the current snapshot, or nil if closed.
+func (c *snapshotConnector) snapshot() *snapshot {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.current == nil {
+		return nil
+	}
+	return c.current.snap
+}
+
+// isCurrent returns true if the specified snapshot reference is the current
+// one.
+func (c *snapshotConnector) isCurrent(ref *snapshotRef) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return ref == c.current
+}
+
+// swap switches new connections over to the specified snapshot, retiring the
+// previous snapshot. It returns ErrClosed if the connector has already been
+// closed, leaving it to the caller to dispose of the snapshot.
+func (c *snapshotConnector) swap(snap *snapshot) error {
+	c.mu.Lock()
+	if c.current == nil {
+		c.mu.Unlock()
+		return ErrClosed
+	}
+	old := c.current
+	c.current = &snapshotRef{snap: snap}
+	c.mu.Unlock()
+	c.retire(old)
+	return nil
+}
+
+// close retires the current snapshot, so that no new connections can be
+// opened anymore.
+func (c *snapshotConnector) close() {
+	c.mu.Lock()
+	old := c.current
+	c.current = nil
+	c.mu.Unlock()
+	if old != nil {
+		c.retire(old)
+	}
+}
+
+// retire the specified snapshot, removing it if there are no connections left
+// to it.
+func (c *snapshotConnector) retire(ref *snapshotRef) {
+	c.mu.Lock()
+	ref.retired = true
+	unused := ref.conns == 0
+	c.mu.Unlock()
+	if unused {
+		ref.snap.remove()
+	}
+}
+
+// release a connection to the specified snapshot, removing the snapshot if it
+// has been retired and this was its last connection.
+func (c *snapshotConnector) release(ref *snapshotRef) {
+	c.mu.Lock()
+	ref.conns--
+	unused := ref.retired && ref.conns == 0
+	c.mu.Unlock()
+	if unused {
+		ref.snap.remove()
+	}
+}
+
+// snapshotConn is a database connection to a particular snapshot. It forwards
+// to the SQLite driver's connection, but reports itself as no longer valid
+// after its snapshot got retired.
+type snapshotConn struct {
+	driver.Conn
+	connector *snapshotConnector
+	ref       *snapshotRef
+	closeOnce sync.Once
+}
+
+var (
+	_ driver.ConnBeginTx        = (*snapshotConn)(nil)
+	_ driver.ConnPrepareContext = (*snapshotConn)(nil)
+	_ driver.QueryerContext     = (*snapshotConn)(nil)
+	_ driver.ExecerContext      = (*snapshotConn)(nil)
+	_ driver.Pinger             = (*snapshotConn)(nil)
+	_ driver.SessionResetter    = (*snapshotConn)(nil)
+	_ driver.Validator          = (*snapshotConn)(nil)
+	_ driver.NamedValueChecker  = (*snapshotConn)(nil)
+)
+
+// Close closes the connection and releases its snapshot.
+func (c *snapshotConn) Close() error {
+	err := c.Conn.Close()
+	c.closeOnce.Do(func() { c.connector.release(c.ref) })
+	return err
+}
+
+// IsValid returns false after the connection's snapshot has been retired, so
+// that the connection doesn't get placed into the connection pool anymore.
+func (c *snapshotConn) IsValid() bool {
+	if !c.connector.isCurrent(c.ref) {
+		return false
+	}
+	if v, ok := c.Conn.(driver.Validator); ok {
+		return v.IsValid()
+	}
+	return true
+}
+
+// ResetSession returns driver.ErrBadConn after the connection's snapshot has
+// been retired, so that the connection doesn't get reused anymore.
+func (c *snapshotConn) ResetSession(ctx context.Context) error {
+	if !c.connector.isCurrent(c.ref) {
+		return driver.ErrBadConn
+	}
+	if r, ok := c.Conn.(driver.SessionResetter); ok {
+		return r.ResetSession(ctx)
+	}
+	return nil
+}
+
+// BeginTx starts a transaction, passing on the transaction options.
+func (c *snapshotConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if b, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return b.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin()
+}
+
+// PrepareContext prepares a statement.
+func (c *snapshotConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if p, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return p.PrepareContext(ctx, query)
+	}
+	return c.Conn.Prepare(query)
+}
+
+// QueryContext runs a query without preparing it first, if supported.
+func (c *snapshotConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if q, ok := c.Conn.(driver.QueryerContext); ok {
+		return q.QueryContext(ctx, query, args)
+	}
+	return nil, driver.ErrSkip
+}
+
+// ExecContext executes a statement without preparing it first, if supported.
+func (c *snapshotConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if e, ok := c.Conn.(driver.ExecerContext); ok {
+		return e.ExecContext(ctx, query, args)
+	}
+	return nil, driver.ErrSkip
+}
+
+// Ping checks that the connection is still alive.
+func (c *snapshotConn) Ping(ctx context.Context) error {
+	if p, ok := c.Conn.(driver.Pinger); ok {
+		return p.Ping(ctx)
+	}
+	return nil
+}
+
+// CheckNamedValue checks query arguments, if supported.
+func (c *snapshotConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if n, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return n.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
@@ -0,0 +1,154 @@
+// (c) Siemens AG 2026
+//
+// SPDX-License-Identifier: MIT
+
+package ieddata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/whalewatcher"
+	"github.com/thediveo/whalewatcher/portfolio"
+)
+
+// composeServiceLabel is the container label with the compose service name.
+const composeServiceLabel = "com.docker.compose.service"
+
+// ContainerState is the state of an app container.
+type ContainerState string
+
+// The states of app containers; only alive containers are considered.
+const (
+	ContainerRunning ContainerState = "running"
+	ContainerPaused  ContainerState = "paused"
+)
+
+// AppContainer describes a container of an installed app.
+type AppContainer struct {
+	ID      string
+	Name    string
+	Project string // compose project name, if any.
+	Service string // compose service name, if any.
+	State   ContainerState
+	PID     model.PIDType // PID of the container's initial process.
+}
+
+// RunningApp describes an installed app together with its alive containers,
+// if any.
+type RunningApp struct {
+	App        App
+	Containers []AppContainer
+}
+
+// AppContainers returns the installed apps with their active versions (see
+// WithActiveVersions), together with their alive containers. Apps without any
+// alive containers are included too, but then without containers.
+//
+// AppContainers queries the same container engines as Open used in order to
+// locate the IE runtime container, see also WithEngines. It returns an error
+// wrapping ErrDockerUnavailable if none of the container engines can be
+// contacted. Similar to ComposeFile, AppContainers only works for databases
+// opened from an IE runtime container.
+//
+// Containers are associated with apps by their compose project names, which
+// need to match either the name of the directory containing an app's compose
+// file, or the app's repository name. Only containers without any compose
+// project name are associated by their compose service names with the service
+// labels of apps instead; containers of other compose projects are never
+// associated with apps.
+func (db *AppEngineDB) AppContainers(ctx context.Context) ([]RunningApp, error) {
+	if db.pid == 0 {
+		return nil, fmt.Errorf("%w: database not opened from inside an IE runtime container",
+			ErrNoRuntimeContainer)
+	}
+	apps, err := db.AppsContext(ctx, WithActiveVersions())
+	if err != nil {
+		return nil, err
+	}
+	var containers []*whalewatcher.Container
+	var engineErrs []error
+	available := false
+	for _, engine := range db.engines {
+		pf, err := enginePortfolio(ctx, engine)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			engineErrs = append(engineErrs, err)
+			continue
+		}
+		available = true
+		containers = append(containers, portfolioContainers(pf)...)
+	}
+	if !available {
+		return nil, fmt.Errorf("%w: %w", ErrDockerUnavailable, errors.Join(engineErrs...))
+	}
+	return appContainers(apps, containers), nil
+}
+
+// portfolioContainers returns all containers from the specified portfolio,
+// including containers not belonging to any compose project.
+func portfolioContainers(pf *portfolio.Portfolio) []*whalewatcher.Container {
+	var containers []*whalewatcher.Container
+	for _, projectName := range pf.Names() {
+		if project := pf.Project(projectName); project != nil {
+			containers = append(containers, project.Containers()...)
+		}
+	}
+	return containers
+}
+
+// appContainers associates the specified containers with the specified apps,
+// returning the apps together with their containers in the order of the apps.
+// Each container is associated with at most one app.
+func appContainers(apps []App, containers []*whalewatcher.Container) []RunningApp {
+	runningApps := make([]RunningApp, len(apps))
+	projectApps := map[string]int{} // lowercase project name to app index
+	serviceApps := map[string]int{} // service name to app index
+	for idx := len(apps) - 1; idx >= 0; idx-- {
+		app := &apps[idx]
+		runningApps[idx].App = *app
+		// Earlier apps win, so we work backwards.
+		if app.ComposerFilepath != "" {
+			projectApps[strings.ToLower(path.Base(path.Dir(app.ComposerFilepath)))] = idx
+		}
+		if app.RepositoryName != "" {
+			projectApps[strings.ToLower(app.RepositoryName)] = idx
+		}
+		if labels, err := app.ParsedServiceLabels(); err == nil {
+			for service := range labels {
+				serviceApps[service] = idx
+			}
+		}
+	}
+	for _, container := range containers {
+		service := container.Labels[composeServiceLabel]
+		appIdx, ok := -1, false
+		if container.Project != "" {
+			appIdx, ok = projectApps[strings.ToLower(container.Project)]
+		} else if service != "" {
+			appIdx, ok = serviceApps[service]
+		}
+		if !ok {
+			continue
+		}
+		state := ContainerRunning
+		if container.Paused {
+			state = ContainerPaused
+		}
+		runningApps[appIdx].Containers = append(runningApps[appIdx].Containers, AppContainer{
+			ID:      container.ID,
+			Name:    container.Name,
+			Project: container.Project,
+			Service: service,
+			State:   state,
+			PID:     model.PIDType(container.PID),
+		})
+	}
+	return runningApps
+}
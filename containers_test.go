@@ -0,0 +1,89 @@
+// (c) Siemens AG 2026
+//
+// SPDX-License-Identifier: MIT
+
+package ieddata
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/whalewatcher"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/thediveo/success"
+)
+
+var _ = Describe("app containers", func() {
+
+	It("associates containers with apps", func(ctx context.Context) {
+		db := Successful(OpenFileContext(ctx, "tests/sqlite-alpine-appengine-db/test-apps-and-device.db"))
+		defer func() { _ = db.Close() }()
+		apps := Successful(db.AppsContext(ctx, WithActiveVersions()))
+
+		runningApps := appContainers(apps, []*whalewatcher.Container{
+			{
+				ID:      "1",
+				Name:    "edgeshark-gostwire-1",
+				Project: "Edgeshark",
+				Labels:  whalewatcher.ContainerLabels{composeServiceLabel: "gostwire"},
+				PID:     42,
+			},
+			{ID: "2", Name: "bbb-foo-1", Project: "bbb", Paused: true, PID: 666},
+			{ID: "3", Name: "ddd", Labels: whalewatcher.ContainerLabels{composeServiceLabel: "ddd"}},
+			{ID: "4", Name: "other-ddd-1", Project: "other", Labels: whalewatcher.ContainerLabels{composeServiceLabel: "ddd"}},
+			{ID: "5", Name: "edge-iot-core"},
+		})
+		Expect(runningApps).To(ConsistOf(
+			And(HaveField("App.Title", "AppA"),
+				HaveField("Containers", HaveExactElements(AppContainer{
+					ID:      "1",
+					Name:    "edgeshark-gostwire-1",
+					Project: "Edgeshark",
+					Service: "gostwire",
+					State:   ContainerRunning,
+					PID:     42,
+				}))),
+			And(HaveField("App.Title", "AppB"),
+				HaveField("Containers", HaveExactElements(And(
+					HaveField("ID", "2"),
+					HaveField("State", ContainerPaused),
+					HaveField("PID", model.PIDType(666)))))),
+			And(HaveField("App.Title", "AppC"),
+				HaveField("Containers", BeEmpty())),
+			And(HaveField("App.Title", "AppD"),
+				HaveField("Containers", HaveExactElements(HaveField("ID", "3")))),
+		))
+	})
+
+	It("prefers earlier apps", func() {
+		runningApps := appContainers([]App{
+			{Id: "a", RepositoryName: "foo"},
+			{Id: "b", ComposerFilepath: "/apps/foo/docker-compose.yml"},
+		}, []*whalewatcher.Container{{ID: "1", Project: "foo"}})
+		Expect(runningApps).To(HaveExactElements(
+			HaveField("Containers", HaveLen(1)),
+			HaveField("Containers", BeEmpty()),
+		))
+	})
+
+	It("reports unavailable container engines", func(ctx context.Context) {
+		cwd := Successful(os.Getwd())
+		db := Successful(open(ctx,
+			filepath.Join(cwd, "tests/sqlite-alpine-appengine-db/test-apps-and-device.db"),
+			model.PIDType(os.Getpid()),
+			WithEngines(DockerEngine("unix:///nowhere/docker.sock"))))
+		defer func() { _ = db.Close() }()
+		Expect(db.AppContainers(ctx)).Error().To(MatchError(ErrDockerUnavailable))
+	})
+
+	It("needs an IE runtime container", func(ctx context.Context) {
+		db := Successful(OpenFileContext(ctx, "tests/sqlite-alpine-appengine-db/test-apps-and-device.db"))
+		defer func() { _ = db.Close() }()
+		Expect(db.AppContainers(ctx)).Error().To(MatchError(ErrNoRuntimeContainer))
+	})
+
+})
@@ -7,43 +7,209 @@ package ieddata
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/whalewatcher"
+	"github.com/thediveo/whalewatcher/portfolio"
+	"github.com/thediveo/whalewatcher/watcher"
+	"github.com/thediveo/whalewatcher/watcher/containerd"
 	"github.com/thediveo/whalewatcher/watcher/moby"
 )
 
 // EdgeIotCoreContainerName is the name of the IED runtime container.
 const EdgeIotCoreContainerName = "edge-iot-core"
 
+// DefaultContainerdAddress is the containerd API endpoint used by default in
+// order to locate the IE runtime container, as seen from the initial mount
+// namespace.
+const DefaultContainerdAddress = "/proc/1/root/run/containerd/containerd.sock"
+
+// DefaultPodmanHost is the Podman (Docker-compatible) API endpoint used by
+// default in order to locate the IE runtime container, as seen from the initial
+// mount namespace.
+const DefaultPodmanHost = "unix:///proc/1/root/run/podman/podman.sock"
+
+// Engine describes a container engine to query when locating the IE runtime
+// container. Use DockerEngine, ContainerdEngine, and PodmanEngine to describe
+// the supported container engines, or plug in other engines by supplying a
+// suitable whalewatcher.Watcher factory. The factory should return the
+// context's error when the context gets cancelled or its deadline passes while
+// still connecting to the API endpoint.
+type Engine struct {
+	Name    string                                                             // engine name for error messages, such as “docker”.
+	Address string                                                             // API endpoint address.
+	New     func(ctx context.Context, address string) (watcher.Watcher, error) // creates a workload watcher for the API endpoint.
+}
+
+// DockerEngine returns an Engine description for a Docker engine with the
+// specified API endpoint, such as DefaultDockerHost.
+func DockerEngine(host string) Engine {
+	return Engine{
+		Name:    "docker",
+		Address: host,
+		New: func(ctx context.Context, address string) (watcher.Watcher, error) {
+			return newWatcher(ctx, func() (watcher.Watcher, error) { return moby.New(address, nil) })
+		},
+	}
+}
+
+// ContainerdEngine returns an Engine description for a containerd engine with
+// the specified API endpoint, such as DefaultContainerdAddress. Please note that
+// containerd's API endpoint is a plain file system path, not an URL.
+func ContainerdEngine(address string) Engine {
+	return Engine{
+		Name:    "containerd",
+		Address: address,
+		New: func(ctx context.Context, address string) (watcher.Watcher, error) {
+			return newWatcher(ctx, func() (watcher.Watcher, error) { return containerd.New(address, nil) })
+		},
+	}
+}
+
+// PodmanEngine returns an Engine description for a Podman engine with the
+// specified Docker-compatible API endpoint, such as DefaultPodmanHost.
+func PodmanEngine(host string) Engine {
+	return Engine{
+		Name:    "podman",
+		Address: host,
+		New: func(ctx context.Context, address string) (watcher.Watcher, error) {
+			return newWatcher(ctx, func() (watcher.Watcher, error) { return moby.New(address, nil) })
+		},
+	}
+}
+
+// newWatcher returns the workload watcher created by the specified watcher
+// constructor. If the context gets cancelled or its deadline passes while the
+// constructor is still busy, such as when dialing an unresponsive API
+// endpoint, newWatcher returns the context's error without waiting for the
+// constructor to finish; a watcher created nevertheless gets closed.
+func newWatcher(ctx context.Context, newfn func() (watcher.Watcher, error)) (watcher.Watcher, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	type result struct {
+		w   watcher.Watcher
+		err error
+	}
+	created := make(chan result, 1)
+	go func() {
+		w, err := newfn()
+		created <- result{w: w, err: err}
+	}()
+	select {
+	case r := <-created:
+		return r.w, r.err
+	case <-ctx.Done():
+		go func() {
+			if r := <-created; r.err == nil {
+				r.w.Close()
+			}
+		}()
+		return nil, ctx.Err()
+	}
+}
+
 // edgeCoreContainerPID returns the PID of the IED's runtime container, if
-// present; otherwise it returns an error.
-func edgeCoreContainerPID() (model.PIDType, error) {
-	// Create a (transient) Docker container alive workload watcher.
-	mobywatcher, err := moby.New("unix:///proc/1/root/run/docker.sock", nil)
+// present; otherwise it returns an error. The container engines to query in
+// turn, as well as the name of the runtime container are taken from the
+// specified options. When the specified context gets cancelled or its deadline
+// passes while still connecting to a container engine or waiting for the
+// initial workload synchronization, edgeCoreContainerPID returns the context's
+// error.
+//
+// If none of the container engines can be contacted, the error returned wraps
+// ErrDockerUnavailable; if at least one container engine could be contacted,
+// but none of them has the runtime container, the error wraps
+// ErrNoRuntimeContainer instead.
+func edgeCoreContainerPID(ctx context.Context, o *options) (model.PIDType, error) {
+	var engineErrs []error
+	available := false
+	for _, engine := range o.engineList() {
+		pf, err := enginePortfolio(ctx, engine)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return 0, ctxErr
+			}
+			engineErrs = append(engineErrs, err)
+			continue
+		}
+		available = true
+		// Now see if there's an IE runtime container somewhere...
+		if core := findContainer(pf, o.coreContainerName); core != nil {
+			return model.PIDType(core.PID), nil
+		}
+	}
+	if !available {
+		return 0, fmt.Errorf("%w: %w", ErrDockerUnavailable, errors.Join(engineErrs...))
+	}
+	return 0, fmt.Errorf("%w: no container named %q", ErrNoRuntimeContainer, o.coreContainerName)
+}
+
+// enginePortfolio returns the portfolio of containers of the specified
+// container engine, after the initial synchronization with the engine's
+// workload has finished. The portfolio does not get updated anymore after
+// enginePortfolio returns.
+func enginePortfolio(ctx context.Context, engine Engine) (*portfolio.Portfolio, error) {
+	// Create a (transient) container alive workload watcher.
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	w, err := engine.New(ctx, engine.Address)
 	if err != nil {
-		return 0, err
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, fmt.Errorf("%s engine at %q: %w", engine.Name, engine.Address, err)
 	}
-	defer mobywatcher.Close()
+	defer w.Close()
 
 	// Then start watching which also triggers the initial synchronization with
 	// the current workload. And wait for the initial synchronization to be
 	// finished.
 	watcherPrematurlyTerminated := make(chan struct{}, 1)
-	ctx, cancel := context.WithCancel(context.Background())
+	var watchErr error
+	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 	go func() {
-		_ = mobywatcher.Watch(ctx)
+		watchErr = w.Watch(ctx)
 		close(watcherPrematurlyTerminated)
 	}()
 	select {
-	case <-mobywatcher.Ready():
+	case <-w.Ready():
 	case <-watcherPrematurlyTerminated:
+		// The watcher might have terminated exactly because the context is
+		// done, so make sure to properly report this instead of some
+		// unavailable engine.
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%s engine at %q: %w", engine.Name, engine.Address, watchErr)
+	case <-ctx.Done():
+		return nil, ctx.Err()
 	}
+	return w.Portfolio(), nil
+}
 
-	// Now see if there's an IE runtime container somewhere...
-	core := mobywatcher.Portfolio().Container(EdgeIotCoreContainerName)
-	if core == nil {
-		return 0, errors.New("no Industrial Edge runtime container present")
+// findContainer returns the container with the specified name from the
+// portfolio, or nil if there is no such container. As containerd workload is
+// organized in namespaces, a container name prefixed by its namespace, such as
+// “foo/edge-iot-core”, matches as well.
+func findContainer(pf *portfolio.Portfolio, name string) *whalewatcher.Container {
+	if c := pf.Container(name); c != nil {
+		return c
+	}
+	for _, projectName := range pf.Names() {
+		project := pf.Project(projectName)
+		if project == nil {
+			continue
+		}
+		for _, c := range project.Containers() {
+			if strings.HasSuffix(c.Name, "/"+name) {
+				return c
+			}
+		}
 	}
-	return model.PIDType(core.PID), nil
+	return nil
 }
@@ -39,12 +39,18 @@ var _ = Describe("IED runtime", func() {
 		DeferCleanup(func(ctx context.Context) {
 			Expect(fakecore.Rename(ctx, EdgeIotCoreContainerName)).To(Succeed())
 		})
-		Expect(edgeCoreContainerPID()).Error().To(HaveOccurred())
+		Expect(edgeCoreContainerPID(ctx, newOptions(nil))).Error().To(HaveOccurred())
 	})
 
-	It("finds the IED runtime", func() {
+	It("honors a cancelled context", func(ctx context.Context) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+		Expect(edgeCoreContainerPID(ctx, newOptions(nil))).Error().To(MatchError(context.Canceled))
+	})
+
+	It("finds the IED runtime", func(ctx context.Context) {
 		By("looking for the IED runtime's PID...")
-		pid := Successful(edgeCoreContainerPID())
+		pid := Successful(edgeCoreContainerPID(ctx, newOptions(nil)))
 		Expect(pid).NotTo(BeZero())
 
 		By("...and container canary file")
@@ -0,0 +1,124 @@
+// (c) Siemens AG 2026
+//
+// SPDX-License-Identifier: MIT
+
+package ieddata
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/procfsroot"
+)
+
+// DatabaseInfo describes a database file in the app engine database directory.
+type DatabaseInfo struct {
+	Name     string    // file name, such as “platformbox.db”.
+	Size     int64     // file size in bytes.
+	Modified time.Time // last modification time.
+	IsSQLite bool      // file has a valid SQLite 3 header.
+}
+
+// ListDatabases returns the database files in the app engine database directory
+// inside the IED runtime container, sorted by name. Directories, as well as
+// the SQLite side files ending in “-wal”, “-journal”, and “-shm”, are skipped.
+// Files not being SQLite 3 databases are still listed, but not flagged as
+// IsSQLite.
+//
+// ListDatabases discovers the IED runtime container the same way as Open does
+// and accepts the same options.
+func ListDatabases(opts ...Option) ([]DatabaseInfo, error) {
+	return ListDatabasesContext(context.Background(), opts...)
+}
+
+// ListDatabasesContext works like ListDatabases, but additionally honors
+// cancellation and deadlines of the specified context while discovering the
+// IED runtime container.
+func ListDatabasesContext(ctx context.Context, opts ...Option) ([]DatabaseInfo, error) {
+	o := newOptions(opts)
+	corePID := o.pid
+	if corePID == 0 {
+		var err error
+		corePID, err = edgeCoreContainerPID(ctx, o)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return listDatabases(o.dbBaseDir, corePID)
+}
+
+// ListDatabasesInPID works like ListDatabases, but additionally requires the
+// PID of the container with the app engine DB(s) to be explicitly specified.
+//
+// Unlike WithPID(0), a zero PID doesn't fall back to discovering the IE runtime
+// container, but instead is rejected as invalid.
+func ListDatabasesInPID(pid model.PIDType, opts ...Option) ([]DatabaseInfo, error) {
+	if pid <= 0 {
+		return nil, fmt.Errorf("%w: invalid container PID %d", ErrNoRuntimeContainer, pid)
+	}
+	return ListDatabasesContext(context.Background(), slices.Concat(opts, []Option{WithPID(pid)})...)
+}
+
+// listDatabases returns the database files in the specified directory inside
+// the mount namespace of the process with the specified PID. Both the directory
+// and its entries are resolved with respect to the process' root directory.
+func listDatabases(dir string, pid model.PIDType) ([]DatabaseInfo, error) {
+	rootpath := fmt.Sprintf("/proc/%d/root", pid)
+	dbdir, err := procfsroot.EvalSymlinks(dir, rootpath, procfsroot.EvalFullPath)
+	if err != nil {
+		return nil, fmt.Errorf("%w: cannot determine full database directory path, reason: %w",
+			ErrDatabaseNotFound, err)
+	}
+	entries, err := os.ReadDir(path.Join(rootpath, dbdir))
+	if err != nil {
+		return nil, fmt.Errorf("%w: cannot read database directory, reason: %w",
+			ErrDatabaseNotFound, err)
+	}
+	dbs := []DatabaseInfo{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if isSideFile(name) {
+			continue
+		}
+		dbpath, err := procfsroot.EvalSymlinks(path.Join(dbdir, name), rootpath, procfsroot.EvalFullPath)
+		if err != nil {
+			continue // dangling symbolic link.
+		}
+		info, err := os.Stat(path.Join(rootpath, dbpath))
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		dbs = append(dbs, DatabaseInfo{
+			Name:     name,
+			Size:     info.Size(),
+			Modified: info.ModTime(),
+			IsSQLite: hasSQLiteHeader(path.Join(rootpath, dbpath)),
+		})
+	}
+	return dbs, nil
+}
+
+// isSideFile returns true if the specified file name is that of an SQLite side
+// file, including the “-shm” shared memory file.
+func isSideFile(name string) bool {
+	return slices.ContainsFunc(slices.Concat(sideFileSuffixes, []string{"-shm"}), func(suffix string) bool {
+		return strings.HasSuffix(name, suffix)
+	})
+}
+
+// hasSQLiteHeader returns true if the specified file has a valid SQLite 3
+// header.
+func hasSQLiteHeader(filename string) bool {
+	f, err := os.Open(filename)
+	if err != nil {
+		return false
+	}
+	defer func() { _ = f.Close() }()
+	return checkHeader(f) == nil
+}
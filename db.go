@@ -5,11 +5,12 @@
 package ieddata
 
 import (
+	"context"
 	"fmt"
-	"io"
-	"os"
 	"path"
+	"path/filepath"
 	"regexp"
+	"slices"
 	"sync"
 
 	"github.com/jmoiron/sqlx"
@@ -30,10 +31,19 @@ const dbBaseDir = "/data/app_engine/db"
 const dbDriverName = "sqlite"
 
 // AppEngineDB implements access to an IED app engine host database.
+//
+// The embedded sqlx.DB stays the same for the lifetime of an AppEngineDB, even
+// when Refresh switches to a new snapshot of the database; it is thus safe to
+// use it directly, concurrently to Refresh.
 type AppEngineDB struct {
 	*sqlx.DB
-	copiedDatabasePath string
-	closeMu            sync.Mutex
+	snaps       *snapshotConnector // connects to the current snapshot, if any.
+	sourcePath  string             // path of the original database, if refreshable.
+	tempDir     string             // where to place temporary database copies.
+	iconBaseDir string             // location of icon URL paths in the runtime container.
+	engines     []Engine           // container engines running the IE runtime and apps.
+	pid         model.PIDType      // PID of the IE runtime container, if any.
+	refreshMu   sync.Mutex         // serializes refreshes.
 }
 
 // Open returns a new database “connection” to the specified app engine DB, such
@@ -46,22 +56,45 @@ type AppEngineDB struct {
 //
 // Open hides the details of discovering the IED runtime container in a way that
 // then gives direct file system access to the app engine DBs inside this
-// container.
-func Open(dbname string) (*AppEngineDB, error) {
-	// no core, no cigar.
-	corePID, err := edgeCoreContainerPID()
-	if err != nil {
-		return nil, err
+// container. Without any options, Open uses the Docker API endpoint
+// DefaultDockerHost in order to locate the IE runtime container named
+// EdgeIotCoreContainerName. Use options such as WithDockerSocket and
+// WithCoreContainerName to adapt Open to differently packaged edge
+// environments, or WithPID to skip the discovery altogether.
+func Open(dbname string, opts ...Option) (*AppEngineDB, error) {
+	return OpenContext(context.Background(), dbname, opts...)
+}
+
+// OpenContext works like Open, but additionally honors cancellation and
+// deadlines of the specified context while discovering the IED runtime
+// container and while copying the app engine database.
+func OpenContext(ctx context.Context, dbname string, opts ...Option) (*AppEngineDB, error) {
+	o := newOptions(opts)
+	corePID := o.pid
+	if corePID == 0 {
+		// no core, no cigar.
+		var err error
+		corePID, err = edgeCoreContainerPID(ctx, o)
+		if err != nil {
+			return nil, err
+		}
 	}
-	return OpenInPID(dbname, corePID)
+	return open(ctx, path.Join(o.dbBaseDir, sanitize(dbname)), corePID, opts...)
 }
 
 // OpenInPID works like Open, but additionally requires the PID of the container
 // with the app engine DB(s) to be explicitly specified. Use OpenInPID when the
 // IED's runtime container PID is already known, such as from an lxkns
-// discovery, as so to skip the IE runtime container discovery.
-func OpenInPID(dbname string, pid model.PIDType) (*AppEngineDB, error) {
-	return open(path.Join(dbBaseDir, sanitize(dbname)), pid)
+// discovery, as so to skip the IE runtime container discovery. For a
+// context-aware variant, use OpenContext together with the WithPID option.
+//
+// Unlike WithPID(0), a zero PID doesn't fall back to discovering the IE runtime
+// container, but instead is rejected as invalid.
+func OpenInPID(dbname string, pid model.PIDType, opts ...Option) (*AppEngineDB, error) {
+	if pid <= 0 {
+		return nil, fmt.Errorf("%w: invalid container PID %d", ErrNoRuntimeContainer, pid)
+	}
+	return OpenContext(context.Background(), dbname, slices.Concat(opts, []Option{WithPID(pid)})...)
 }
 
 var onlyAlphaNumsAndMore = regexp.MustCompile(`[^a-zA-Z0-9\-_.]+`)
@@ -89,66 +122,91 @@ func sanitize(basename string) string {
 // https://github.com/mathaou/termdbms/blob/be6f397196077cc7c9ced86e6460470e3b223f3e/main.go#L132.
 //
 // Well, what's good for the goose is good for the gander, so copy it is. Sigh.
-func open(name string, pid model.PIDType) (*AppEngineDB, error) {
+// The temporary copy is placed into the directory specified using WithTempDir,
+// if any. Copying the database stops early when the specified context is done.
+// In order to get a consistent snapshot, the copy includes the write-ahead log
+// and rollback journal files, if present, and is verified using SQLite's
+// integrity check. Please see takeSnapshot for details.
+func open(ctx context.Context, name string, pid model.PIDType, opts ...Option) (*AppEngineDB, error) {
+	o := newOptions(opts)
 	rootpath := fmt.Sprintf("/proc/%d/root", pid)
 	dbpath, err := procfsroot.EvalSymlinks(name, rootpath, procfsroot.EvalFullPath)
 	if err != nil {
-		return nil, fmt.Errorf("cannot determine full database path, reason: %w", err)
+		return nil, fmt.Errorf("%w: cannot determine full database path, reason: %w",
+			ErrDatabaseNotFound, err)
 	}
-	dbpath = path.Join(rootpath, dbpath)
-
-	// Make a temporary copy of the database so we can open it successfully in
-	// all our cases.
-	origdbf, err := os.Open(dbpath)
+	db, err := openFile(ctx, path.Join(rootpath, dbpath), o)
 	if err != nil {
-		return nil, fmt.Errorf("unable to open database, reason: %w", err)
+		return nil, err
 	}
-	defer func() { _ = origdbf.Close() }()
-	tmpdbf, err := os.CreateTemp("", "temp-db-copy-*")
+	db.pid = pid
+	return db, nil
+}
+
+// OpenFile opens the app engine database file at the specified path, such as
+// a “platformbox.db” copied off a device for offline analysis. OpenFile doesn't
+// need any IE runtime container; otherwise, it works like Open, opening only a
+// temporary copy of the database. Only the WithTempDir option applies to
+// OpenFile.
+func OpenFile(filename string, opts ...Option) (*AppEngineDB, error) {
+	return OpenFileContext(context.Background(), filename, opts...)
+}
+
+// OpenFileContext works like OpenFile, but additionally honors cancellation and
+// deadlines of the specified context while copying the app engine database.
+func OpenFileContext(ctx context.Context, filename string, opts ...Option) (*AppEngineDB, error) {
+	abspath, err := filepath.Abs(filename)
 	if err != nil {
-		return nil, fmt.Errorf("unable to open database, reason: %w", err)
-	}
-	defer func() { _ = tmpdbf.Close() }()
-	if _, err := io.Copy(tmpdbf, origdbf); err != nil {
-		_ = os.Remove(tmpdbf.Name())
-		return nil, fmt.Errorf("unable to open database, reason: %w", err)
+		return nil, fmt.Errorf("%w: cannot determine full database path, reason: %w",
+			ErrDatabaseNotFound, err)
 	}
+	return openFile(ctx, abspath, newOptions(opts))
+}
 
-	// As sql.Open might just "validate its parameters" and this might mean near
-	// to nothing, we explicitly ping the database in order to see that it is
-	// okay.
-	dbpath = tmpdbf.Name()
-	_ = tmpdbf.Close()
-	db, err := sqlx.Open(dbDriverName, dbpath)
+// openFile opens a temporary copy of the SQLite database at the specified
+// (host) path.
+func openFile(ctx context.Context, dbpath string, o *options) (*AppEngineDB, error) {
+	// Make a temporary copy of the database so we can open it successfully in
+	// all our cases.
+	snap, err := takeSnapshot(ctx, dbpath, o.tempDir)
 	if err != nil {
 		return nil, err
 	}
-	if err := db.Ping(); err != nil {
+	if err := snap.verify(ctx); err != nil {
+		snap.remove()
 		return nil, err
 	}
 
-	// Install a default mapper function that preserves camelCase, with the
-	// first letter always being lowercase.
-	db.MapperFunc(FirstLower)
-
 	// Success, now wrap the sql.DB object in our AppEngineDB object, so that we
 	// later correctly can clean up when the Close method gets called.
+	db, snaps := newSnapshotDB(snap)
 	return &AppEngineDB{
-		DB:                 db,
-		copiedDatabasePath: dbpath,
+		DB:          db,
+		snaps:       snaps,
+		sourcePath:  dbpath,
+		tempDir:     o.tempDir,
+		iconBaseDir: o.iconBaseDir,
+		engines:     o.engineList(),
 	}, nil
 }
 
+// currentSnapshot returns the snapshot new queries currently go to, or nil if
+// there is none.
+func (db *AppEngineDB) currentSnapshot() *snapshot {
+	if db.snaps == nil {
+		return nil
+	}
+	return db.snaps.snapshot()
+}
+
 // Close closes the database connection and ensures to additionally dispose of
 // the helper resources required to read from an SQLite database in another
-// container.
+// container. The temporary database copy gets removed as soon as all
+// connections to it have been closed.
 func (db *AppEngineDB) Close() error {
-	db.closeMu.Lock()
-	defer db.closeMu.Unlock()
 	err := db.DB.Close()
-	if db.copiedDatabasePath != "" {
-		_ = os.Remove(db.copiedDatabasePath)
-		db.copiedDatabasePath = ""
+	if db.snaps != nil {
+		db.snaps.close()
 	}
 	return err
 }
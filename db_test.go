@@ -6,12 +6,16 @@ package ieddata
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path"
+	"strings"
 	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/whalewatcher/watcher"
 	"golang.org/x/sys/unix"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -73,7 +77,8 @@ var _ = Describe("IED app engine database", func() {
 	})
 
 	It("fails for invalid container PID", func() {
-		Expect(OpenInPID("foo.db", 0)).Error().To(MatchError(MatchRegexp(`no such file or directory`)))
+		Expect(OpenInPID("foo.db", 0)).Error().To(MatchError(ErrNoRuntimeContainer))
+		Expect(OpenInPID(PlatformBoxDb, 0)).Error().To(MatchError(ContainSubstring("invalid container PID 0")))
 	})
 
 	It("fails for missing IE runtime container", func(ctx context.Context) {
@@ -83,11 +88,37 @@ var _ = Describe("IED app engine database", func() {
 		})
 
 		Expect(Open("foo.db")).Error().To(MatchError(MatchRegexp(`no .* runtime container`)))
+		Expect(Open("foo.db")).Error().To(MatchError(ErrNoRuntimeContainer))
 	})
 
 	It("fails for missing/invalid IED app engine database", func() {
 		Expect(Open("foo.db")).Error().To(MatchError(ContainSubstring("/root/data")))
+		Expect(Open("foo.db")).Error().To(MatchError(ErrDatabaseNotFound))
 		Expect(Open("not.a.db")).Error().To(MatchError(ContainSubstring("unable to open database")))
+		Expect(Open("not.a.db")).Error().To(MatchError(ErrNotADatabase))
+	})
+
+	It("lists the app engine databases", func() {
+		Expect(ListDatabases()).To(ConsistOf(
+			And(HaveField("Name", PlatformBoxDb), HaveField("IsSQLite", BeTrue()))))
+	})
+
+	It("correlates apps with containers", func(ctx context.Context) {
+		db := Successful(OpenContext(ctx, PlatformBoxDb))
+		defer func() { _ = db.Close() }()
+		Expect(db.AppContainers(ctx)).To(ConsistOf(
+			And(HaveField("App.Title", "AppA"), HaveField("Containers", BeEmpty())),
+			And(HaveField("App.Title", "AppB"), HaveField("Containers", HaveExactElements(AppContainer{
+				ID:      fakeapp.ID,
+				Name:    "bbb-foo-1",
+				Project: "bbb",
+				Service: "foo",
+				State:   ContainerRunning,
+				PID:     model.PIDType(Successful(fakeapp.PID(ctx))),
+			}))),
+			And(HaveField("App.Title", "AppC"), HaveField("Containers", BeEmpty())),
+			And(HaveField("App.Title", "AppD"), HaveField("Containers", BeEmpty())),
+		))
 	})
 
 	It("accesses the app engine database", func() {
@@ -107,3 +138,160 @@ var _ = Describe("IED app engine database", func() {
 	})
 
 })
+
+var _ = Describe("IED app engine database with context", func() {
+
+	It("doesn't open when the context is already done", func(ctx context.Context) {
+		cwd := Successful(os.Getwd())
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+		Expect(OpenContext(ctx, "test-apps-and-device.db",
+			WithPID(model.PIDType(os.Getpid())),
+			WithDBBaseDir(path.Join(cwd, "tests/sqlite-alpine-appengine-db")))).Error().
+			To(MatchError(context.Canceled))
+	})
+
+	It("rejects invalid PIDs without touching the caller's options", func() {
+		Expect(OpenInPID(PlatformBoxDb, 0)).Error().To(MatchError(ErrNoRuntimeContainer))
+
+		cwd := Successful(os.Getwd())
+		opts := make([]Option, 1, 2)
+		opts[0] = WithDBBaseDir(path.Join(cwd, "tests/sqlite-alpine-appengine-db"))
+		db := Successful(OpenInPID("test-apps-and-device.db", model.PIDType(os.Getpid()), opts...))
+		defer func() { _ = db.Close() }()
+		Expect(opts[:2][1]).To(BeNil())
+	})
+
+	It("reports unavailable container engines", func(ctx context.Context) {
+		Expect(OpenContext(ctx, PlatformBoxDb, WithEngines(
+			DockerEngine("unix:///nowhere/docker.sock"),
+			ContainerdEngine("/nowhere/containerd.sock"),
+			PodmanEngine("unix:///nowhere/podman.sock"),
+			Engine{
+				Name: "fake",
+				New: func(context.Context, string) (watcher.Watcher, error) {
+					return nil, errors.New("fake engine failure")
+				},
+			},
+		))).Error().To(SatisfyAll(
+			MatchError(ErrDockerUnavailable),
+			MatchError(ContainSubstring("fake engine failure"))))
+	})
+
+	It("doesn't contact container engines when the context is already done", func(ctx context.Context) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+		called := false
+		Expect(OpenContext(ctx, PlatformBoxDb, WithEngines(Engine{
+			Name: "fake",
+			New: func(context.Context, string) (watcher.Watcher, error) {
+				called = true
+				return nil, errors.New("fake engine failure")
+			},
+		}))).Error().To(MatchError(context.Canceled))
+		Expect(called).To(BeFalse())
+	})
+
+	It("stops waiting for container engine connections when the context is done", func(ctx context.Context) {
+		ctx, cancel := context.WithCancel(ctx)
+		release := make(chan struct{})
+		defer close(release)
+		go func() {
+			defer GinkgoRecover()
+			time.Sleep(100 * time.Millisecond)
+			cancel()
+		}()
+		Expect(newWatcher(ctx, func() (watcher.Watcher, error) {
+			<-release
+			return nil, errors.New("fake engine failure")
+		})).Error().To(MatchError(context.Canceled))
+	})
+
+	It("reports missing and invalid databases", func(ctx context.Context) {
+		cwd := Successful(os.Getwd())
+		Expect(OpenContext(ctx, "foo.db",
+			WithPID(model.PIDType(os.Getpid())),
+			WithDBBaseDir(path.Join(cwd, "tests/sqlite-alpine-appengine-db")))).Error().
+			To(MatchError(ErrDatabaseNotFound))
+
+		tmpdir := GinkgoT().TempDir()
+		Expect(os.WriteFile(path.Join(tmpdir, "foo.db"), []byte("foobar"), 0644)).To(Succeed())
+		Expect(os.Mkdir(path.Join(tmpdir, "dir.db"), 0755)).To(Succeed())
+		for _, name := range []string{"foo.db", "dir.db"} {
+			Expect(OpenContext(ctx, name,
+				WithPID(model.PIDType(os.Getpid())),
+				WithDBBaseDir(tmpdir))).Error().
+				To(MatchError(ErrNotADatabase), "database %q", name)
+		}
+	})
+
+	It("reports the wrong database", func(ctx context.Context) {
+		tmpdir := GinkgoT().TempDir()
+		sqldb := Successful(sqlx.Open(dbDriverName, path.Join(tmpdir, "wrong.db")))
+		Expect(sqldb.Exec("CREATE TABLE foo (bar TEXT)")).Error().NotTo(HaveOccurred())
+		Expect(sqldb.Close()).To(Succeed())
+
+		db := Successful(OpenContext(ctx, "wrong.db",
+			WithPID(model.PIDType(os.Getpid())),
+			WithDBBaseDir(tmpdir)))
+		defer func() { _ = db.Close() }()
+		Expect(db.AppsContext(ctx)).Error().To(MatchError(ErrWrongDatabase))
+		Expect(db.DeviceInfoContext(ctx)).Error().To(MatchError(ErrWrongDatabase))
+	})
+
+	It("opens offline database files", func(ctx context.Context) {
+		db := Successful(OpenFileContext(ctx, "tests/sqlite-alpine-appengine-db/test-apps-and-device.db"))
+		defer func() { _ = db.Close() }()
+		Expect(db.AppsContext(ctx)).To(HaveLen(4))
+		Expect(db.DeviceInfoContext(ctx)).To(HaveKeyWithValue("deviceName", "iedx12345"))
+
+		Expect(OpenFile("tests/sqlite-alpine-appengine-db/foo.db")).Error().To(MatchError(ErrDatabaseNotFound))
+		Expect(OpenFile("tests/sqlite-alpine-appengine-db/Dockerfile")).Error().To(MatchError(ErrNotADatabase))
+	})
+
+	It("lists databases", func() {
+		tmpdir := GinkgoT().TempDir()
+		Expect(os.WriteFile(path.Join(tmpdir, PlatformBoxDb),
+			Successful(os.ReadFile("tests/sqlite-alpine-appengine-db/test-apps-and-device.db")),
+			0600)).To(Succeed())
+		for _, name := range []string{PlatformBoxDb + "-wal", PlatformBoxDb + "-shm", "foo.txt"} {
+			Expect(os.WriteFile(path.Join(tmpdir, name), []byte("foobar"), 0600)).To(Succeed())
+		}
+		Expect(os.Mkdir(path.Join(tmpdir, "not.a.db"), 0755)).To(Succeed())
+		Expect(os.Symlink(PlatformBoxDb, path.Join(tmpdir, "link.db"))).To(Succeed())
+		Expect(os.Symlink("nowhere.db", path.Join(tmpdir, "dangling.db"))).To(Succeed())
+
+		Expect(ListDatabasesInPID(model.PIDType(os.Getpid()), WithDBBaseDir(tmpdir))).To(HaveExactElements(
+			And(HaveField("Name", "foo.txt"), HaveField("Size", int64(6)), HaveField("IsSQLite", BeFalse())),
+			And(HaveField("Name", "link.db"), HaveField("IsSQLite", BeTrue())),
+			And(HaveField("Name", PlatformBoxDb),
+				HaveField("Size", BeNumerically(">", 0)),
+				HaveField("Modified", Not(BeZero())),
+				HaveField("IsSQLite", BeTrue())),
+		))
+
+		Expect(ListDatabasesInPID(model.PIDType(os.Getpid()), WithDBBaseDir(path.Join(tmpdir, "nowhere")))).
+			Error().To(MatchError(ErrDatabaseNotFound))
+	})
+
+	It("rejects invalid PIDs when listing databases without touching the caller's options", func() {
+		Expect(ListDatabasesInPID(0)).Error().To(MatchError(ErrNoRuntimeContainer))
+		Expect(ListDatabasesInPID(-1)).Error().To(MatchError(ContainSubstring("invalid container PID -1")))
+
+		cwd := Successful(os.Getwd())
+		opts := make([]Option, 1, 2)
+		opts[0] = WithDBBaseDir(path.Join(cwd, "tests/sqlite-alpine-appengine-db"))
+		Expect(ListDatabasesInPID(model.PIDType(os.Getpid()), opts...)).NotTo(BeEmpty())
+		Expect(opts[:2][1]).To(BeNil())
+	})
+
+	It("stops copying when the context is done", func(ctx context.Context) {
+		ctx, cancel := context.WithCancel(ctx)
+		r := &contextReader{ctx: ctx, r: strings.NewReader("foobar")}
+		buff := make([]byte, 3)
+		Expect(r.Read(buff)).To(Equal(3))
+		cancel()
+		Expect(r.Read(buff)).Error().To(MatchError(context.Canceled))
+	})
+
+})
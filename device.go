@@ -4,12 +4,94 @@
 
 package ieddata
 
+import (
+	"context"
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sensitiveDeviceKeys lists the keys of the device table whose values are
+// secrets, such as passwords and tokens.
+var sensitiveDeviceKeys = []string{
+	"password",
+	"boxToken",
+	"boxRefreshToken",
+	"boxCode",
+}
+
+// SensitiveDeviceKeys returns the keys of the device table whose values are
+// secrets, such as passwords and tokens. Unless explicitly asked not to using
+// WithoutRedaction, DeviceInfo and Device replace the values of these keys
+// with RedactedValue. Use WithRedaction to redact further keys.
+func SensitiveDeviceKeys() []string {
+	return slices.Clone(sensitiveDeviceKeys)
+}
+
+// RedactedValue is the value sensitive device information gets replaced with
+// when redacting.
+const RedactedValue = "[REDACTED]"
+
+// DeviceInfoOption configures how to return device information.
+type DeviceInfoOption func(*deviceInfoOptions)
+
+type deviceInfoOptions struct {
+	noRedaction bool     // return sensitive values verbatim.
+	redactKeys  []string // further keys to redact in addition to the sensitive ones.
+}
+
+// WithRedaction additionally masks the values of the specified keys, besides
+// the values of the sensitive device keys as returned by SensitiveDeviceKeys.
+// WithRedaction cancels any WithoutRedaction option specified before it.
+func WithRedaction(keys ...string) DeviceInfoOption {
+	return func(o *deviceInfoOptions) {
+		o.noRedaction = false
+		o.redactKeys = append(o.redactKeys, keys...)
+	}
+}
+
+// WithoutRedaction returns sensitive device information, such as passwords and
+// tokens, verbatim instead of masking them. Use this option only when you
+// really need these secrets and then take care to not leak them.
+func WithoutRedaction() DeviceInfoOption {
+	return func(o *deviceInfoOptions) {
+		o.noRedaction = true
+		o.redactKeys = nil
+	}
+}
+
+// redactedKeys returns the keys whose values to redact.
+func (o *deviceInfoOptions) redactedKeys() []string {
+	if o.noRedaction {
+		return nil
+	}
+	return slices.Concat(sensitiveDeviceKeys, o.redactKeys)
+}
+
 // DeviceInfo returns the key-value pairs describing an IED as per the device
-// table in a platformbox.db.
-func (db *AppEngineDB) DeviceInfo() (map[string]string, error) {
-	rows, err := db.Query("SELECT deviceKey, deviceValue from device")
+// table in a platformbox.db. Sensitive information, such as passwords and
+// tokens, gets masked unless the WithoutRedaction option is specified.
+func (db *AppEngineDB) DeviceInfo(opts ...DeviceInfoOption) (map[string]string, error) {
+	return db.DeviceInfoContext(context.Background(), opts...)
+}
+
+// DeviceInfoContext works like DeviceInfo, but additionally honors
+// cancellation and deadlines of the specified context while querying the
+// database.
+func (db *AppEngineDB) DeviceInfoContext(ctx context.Context, opts ...DeviceInfoOption) (map[string]string, error) {
+	var o deviceInfoOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	rows, err := db.DB.QueryContext(ctx, "SELECT deviceKey, deviceValue from device")
 	if err != nil {
-		return nil, err
+		return nil, wrongDatabaseError(err)
 	}
 	defer rows.Close()
 	devinfo := map[string]string{}
@@ -20,5 +102,198 @@ func (db *AppEngineDB) DeviceInfo() (map[string]string, error) {
 		}
 		devinfo[key] = value
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	redact(devinfo, o.redactedKeys())
 	return devinfo, nil
 }
+
+// redact replaces the non-empty values of the specified keys with
+// RedactedValue.
+func redact(devinfo map[string]string, keys []string) {
+	for _, key := range keys {
+		if devinfo[key] != "" {
+			devinfo[key] = RedactedValue
+		}
+	}
+}
+
+// Device describes an IED as per the device table in a platformbox.db, with
+// the values of well-known keys parsed into their proper types. Values of keys
+// not known to Device are kept verbatim in Extra, so that no information gets
+// lost when new keys get added.
+//
+// Similar to App, field names almost always match their corresponding device
+// keys, but with an initial uppercase letter.
+type Device struct {
+	DeviceId               string     `db:"deviceId"`
+	NodeId                 string     `db:"nodeId"`
+	DeviceName             string     `db:"deviceName"`
+	OwnerEmail             string     `db:"ownerEmail"`
+	OwnerName              string     `db:"ownerName"`
+	OwnerUserId            string     `db:"ownerUserId"`
+	PortalOwnerUserId      string     `db:"portalownerUserId"`
+	LogoFile               string     `db:"logoFile"`
+	ActivationDate         time.Time  `db:"activationDate"`
+	EdgeMode               EdgeMode   `db:"edgeMode"`
+	IsDeviceModelAvailable bool       `db:"isDeviceModelAvailable"`
+	PortalURL              string     `db:"portalUrl"`
+	PortalRepo             string     `db:"portalRepo"`
+	DomainName             string     `db:"domainName"`
+	IsAutoRenewCertificate bool       `db:"isAutoRenewCertificate"`
+	DeveloperMode          bool       `db:"developerMode"`
+	IEDVersion             IEDVersion `db:"iedVersion"`
+	L2NetworkOnIEM         bool       `db:"l2NetworkOnIEM"`
+	IPAddress              string     `db:"ipAddress"`
+
+	// Extra contains the key-value pairs from the device table that either
+	// aren't known to Device or whose values couldn't be parsed.
+	Extra map[string]string `db:"-"`
+}
+
+// EdgeMode describes how an IED is managed. As EdgeMode is a string type,
+// modes unknown to this package are kept as-is.
+type EdgeMode string
+
+// Known edge modes.
+const (
+	EdgeModeBackendManaged EdgeMode = "backendManaged" // managed by an Industrial Edge Management
+)
+
+// IEDVersion is the parsed version of the IED software, such as
+// “siemens-vied-buster-1.3.1-1-a”.
+type IEDVersion struct {
+	Raw    string // unparsed version string, such as “siemens-vied-buster-1.3.1-1-a”.
+	Flavor string // flavor, such as “siemens-vied-buster”.
+	Major  int
+	Minor  int
+	Patch  int
+	Suffix string // any suffix following the semantic version, such as “1-a”.
+}
+
+var iedVersionRe = regexp.MustCompile(`^(?:(.*?)-)?(\d+)\.(\d+)\.(\d+)(?:-(.*))?$`)
+
+// ParseIEDVersion parses an IED version string, such as
+// “siemens-vied-buster-1.3.1-1-a”, returning an error if the version string
+// lacks a major.minor.patch version.
+func ParseIEDVersion(s string) (IEDVersion, error) {
+	m := iedVersionRe.FindStringSubmatch(s)
+	if m == nil {
+		return IEDVersion{}, fmt.Errorf("invalid IED version %q", s)
+	}
+	v := IEDVersion{Raw: s, Flavor: m[1], Suffix: m[5]}
+	var err error
+	if v.Major, err = strconv.Atoi(m[2]); err != nil {
+		return IEDVersion{}, fmt.Errorf("invalid IED version %q: %w", s, err)
+	}
+	if v.Minor, err = strconv.Atoi(m[3]); err != nil {
+		return IEDVersion{}, fmt.Errorf("invalid IED version %q: %w", s, err)
+	}
+	if v.Patch, err = strconv.Atoi(m[4]); err != nil {
+		return IEDVersion{}, fmt.Errorf("invalid IED version %q: %w", s, err)
+	}
+	return v, nil
+}
+
+// String returns the unparsed IED version string.
+func (v IEDVersion) String() string { return v.Raw }
+
+// UnmarshalText parses the specified IED version string.
+func (v *IEDVersion) UnmarshalText(text []byte) error {
+	parsed, err := ParseIEDVersion(string(text))
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
+// MarshalText returns the unparsed IED version string.
+func (v IEDVersion) MarshalText() ([]byte, error) { return []byte(v.Raw), nil }
+
+// Device returns the information describing an IED as per the device table in
+// a platformbox.db, with the values of well-known keys parsed into their
+// proper types. Sensitive information, such as passwords and tokens, ends up
+// masked in the Extra map, unless the WithoutRedaction option is specified.
+func (db *AppEngineDB) Device(opts ...DeviceInfoOption) (*Device, error) {
+	return db.DeviceContext(context.Background(), opts...)
+}
+
+// DeviceContext works like Device, but additionally honors cancellation and
+// deadlines of the specified context while querying the database.
+func (db *AppEngineDB) DeviceContext(ctx context.Context, opts ...DeviceInfoOption) (*Device, error) {
+	devinfo, err := db.DeviceInfoContext(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return newDevice(devinfo), nil
+}
+
+// deviceTimeLayouts lists the layouts of date/time values in the device table,
+// in the order to try them.
+var deviceTimeLayouts = []string{
+	time.DateTime,
+	time.RFC3339,
+}
+
+var textUnmarshalerT = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+// newDevice returns a new Device object, populated from the specified device
+// key-value pairs. Keys without a corresponding Device field as well as values
+// that fail to parse end up in the Extra map.
+func newDevice(devinfo map[string]string) *Device {
+	dev := &Device{Extra: map[string]string{}}
+	devV := reflect.ValueOf(dev).Elem()
+	devT := devV.Type()
+	keyFieldIndices := map[string]int{}
+	for fieldIdx := range devT.NumField() {
+		key := devT.Field(fieldIdx).Tag.Get("db")
+		if key == "-" {
+			continue
+		}
+		if key == "" {
+			key = FirstLower(devT.Field(fieldIdx).Name)
+		}
+		keyFieldIndices[key] = fieldIdx
+	}
+	for key, value := range devinfo {
+		fieldIdx, ok := keyFieldIndices[key]
+		if !ok || setDeviceField(devV.Field(fieldIdx), value) != nil {
+			dev.Extra[key] = value
+		}
+	}
+	return dev
+}
+
+// setDeviceField sets the specified Device field to the parsed value, or
+// returns an error if the value cannot be parsed.
+func setDeviceField(field reflect.Value, value string) error {
+	// Please note that time.Time is a text unmarshaller, too, but only for
+	// RFC3339, so we need to check for it first.
+	if _, ok := field.Interface().(time.Time); ok {
+		for _, layout := range deviceTimeLayouts {
+			if t, err := time.Parse(layout, value); err == nil {
+				field.Set(reflect.ValueOf(t))
+				return nil
+			}
+		}
+		return fmt.Errorf("invalid date/time %q", value)
+	}
+	if field.Addr().Type().Implements(textUnmarshalerT) {
+		return field.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value))
+	}
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+		return nil
+	case reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+		return nil
+	}
+	return errors.New("unsupported field type " + field.Type().String())
+}
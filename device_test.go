@@ -5,9 +5,13 @@
 package ieddata
 
 import (
+	"context"
 	"os"
+	"path"
 	"time"
 
+	"github.com/thediveo/lxkns/model"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	. "github.com/thediveo/fdooze"
@@ -38,3 +42,96 @@ var _ = Describe("device info", func() {
 	})
 
 })
+
+var _ = Describe("typed device information", func() {
+
+	It("returns typed device information", func(ctx context.Context) {
+		cwd := Successful(os.Getwd())
+		db := Successful(open(ctx, path.Join(cwd, "tests/sqlite-alpine-appengine-db/test-apps-and-device.db"), model.PIDType(os.Getpid())))
+		defer func() { _ = db.Close() }()
+
+		dev := Successful(db.DeviceContext(ctx))
+		Expect(dev.DeviceName).To(Equal("iedx12345"))
+		Expect(dev.OwnerEmail).To(Equal("foo.bar@example.com"))
+		Expect(dev.ActivationDate).To(Equal(time.Date(2021, 10, 20, 12, 45, 1, 0, time.UTC)))
+		Expect(dev.EdgeMode).To(Equal(EdgeModeBackendManaged))
+		Expect(dev.IsAutoRenewCertificate).To(BeTrue())
+		Expect(dev.DeveloperMode).To(BeFalse())
+		Expect(dev.PortalURL).To(Equal("partners.edge.siemens.cloud:443"))
+		Expect(dev.IEDVersion).To(Equal(IEDVersion{
+			Raw:    "siemens-vied-buster-1.3.1-1-a",
+			Flavor: "siemens-vied-buster",
+			Major:  1, Minor: 3, Patch: 1,
+			Suffix: "1-a",
+		}))
+		Expect(dev.Extra).To(HaveKeyWithValue("heartBeatPublishedTime", "123"))
+		Expect(dev.Extra).NotTo(HaveKey("deviceName"))
+	})
+
+	It("redacts sensitive device information", func(ctx context.Context) {
+		cwd := Successful(os.Getwd())
+		db := Successful(open(ctx, path.Join(cwd, "tests/sqlite-alpine-appengine-db/test-apps-and-device.db"), model.PIDType(os.Getpid())))
+		defer func() { _ = db.Close() }()
+
+		m := Successful(db.DeviceInfoContext(ctx))
+		for _, key := range SensitiveDeviceKeys() {
+			Expect(m).To(HaveKeyWithValue(key, RedactedValue))
+		}
+		Expect(m).To(HaveKeyWithValue("deviceName", "iedx12345"))
+		Expect(m).To(HaveKeyWithValue("ownerEmail", "foo.bar@example.com"))
+
+		m = Successful(db.DeviceInfoContext(ctx, WithRedaction("ownerEmail")))
+		Expect(m).To(HaveKeyWithValue("password", RedactedValue))
+		Expect(m).To(HaveKeyWithValue("ownerEmail", RedactedValue))
+
+		dev := Successful(db.DeviceContext(ctx))
+		Expect(dev.Extra).To(HaveKeyWithValue("password", RedactedValue))
+		Expect(dev.Extra).To(HaveKeyWithValue("boxToken", RedactedValue))
+		Expect(dev.OwnerEmail).To(Equal("foo.bar@example.com"))
+	})
+
+	It("returns sensitive device information only when asked to", func(ctx context.Context) {
+		cwd := Successful(os.Getwd())
+		db := Successful(open(ctx, path.Join(cwd, "tests/sqlite-alpine-appengine-db/test-apps-and-device.db"), model.PIDType(os.Getpid())))
+		defer func() { _ = db.Close() }()
+
+		Expect(db.DeviceInfoContext(ctx, WithRedaction("ownerEmail"), WithoutRedaction())).To(And(
+			HaveKeyWithValue("password", "passw0rt"),
+			HaveKeyWithValue("ownerEmail", "foo.bar@example.com")))
+		Expect(db.DeviceContext(ctx, WithoutRedaction())).To(
+			HaveField("Extra", HaveKeyWithValue("password", "passw0rt")))
+		Expect(db.DeviceInfoContext(ctx, WithoutRedaction(), WithRedaction())).To(
+			HaveKeyWithValue("password", RedactedValue))
+
+		keys := SensitiveDeviceKeys()
+		keys[0] = "foo"
+		Expect(SensitiveDeviceKeys()).To(ContainElement("password"))
+	})
+
+	It("keeps unknown keys and unparsable values", func() {
+		dev := newDevice(map[string]string{
+			"deviceName":     "foo",
+			"developerMode":  "perhaps",
+			"activationDate": "yesterday",
+			"iedVersion":     "v1",
+			"fooBar":         "baz",
+		})
+		Expect(dev.DeviceName).To(Equal("foo"))
+		Expect(dev.DeveloperMode).To(BeFalse())
+		Expect(dev.ActivationDate).To(BeZero())
+		Expect(dev.Extra).To(Equal(map[string]string{
+			"developerMode":  "perhaps",
+			"activationDate": "yesterday",
+			"iedVersion":     "v1",
+			"fooBar":         "baz",
+		}))
+	})
+
+	It("parses IED versions", func() {
+		Expect(ParseIEDVersion("1.2.3")).To(Equal(IEDVersion{Raw: "1.2.3", Major: 1, Minor: 2, Patch: 3}))
+		Expect(ParseIEDVersion("foo-bar-10.20.30")).To(Equal(IEDVersion{
+			Raw: "foo-bar-10.20.30", Flavor: "foo-bar", Major: 10, Minor: 20, Patch: 30}))
+		Expect(ParseIEDVersion("foo")).Error().To(HaveOccurred())
+	})
+
+})
@@ -0,0 +1,147 @@
+// (c) Siemens AG 2026
+//
+// SPDX-License-Identifier: MIT
+
+package ieddata
+
+import (
+	"reflect"
+	"slices"
+)
+
+// AppsDiff describes the differences between two app inventories, keyed by
+// their app IDs.
+type AppsDiff struct {
+	Added   []App       // apps only in the second inventory.
+	Removed []App       // apps only in the first inventory.
+	Changed []AppChange // apps in both inventories, but with changed fields.
+}
+
+// AppChange describes the changes to an app present in both inventories.
+type AppChange struct {
+	Before App
+	After  App
+	Fields []FieldChange // changed fields, in the order of DiffedAppFields.
+}
+
+// FieldChange describes the change of a single App field.
+type FieldChange struct {
+	Field  string // App field name, such as “Version”.
+	Before any
+	After  any
+}
+
+// diffedAppFields lists the names of the App fields that DiffApps compares.
+var diffedAppFields = []string{
+	"Version",
+	"VersionId",
+	"VersionStatus",
+	"AppStatus",
+	"IsVisible",
+	"RedirectType",
+	"RedirectUrl",
+	"RESTRedirectUrl",
+	"RedirectSection",
+}
+
+// diffedAppFieldIndices are the indices of the diffedAppFields in App. Looking
+// them up once ensures that all diffedAppFields actually exist.
+var diffedAppFieldIndices = func() [][]int {
+	appT := reflect.TypeFor[App]()
+	indices := make([][]int, 0, len(diffedAppFields))
+	for _, name := range diffedAppFields {
+		field, ok := appT.FieldByName(name)
+		if !ok {
+			panic("unknown App field " + name)
+		}
+		indices = append(indices, field.Index)
+	}
+	return indices
+}()
+
+// DiffedAppFields returns the names of the App fields that DiffApps compares.
+func DiffedAppFields() []string {
+	return slices.Clone(diffedAppFields)
+}
+
+// IsEmpty returns true if there are no differences.
+func (d AppsDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Has returns true if any of the specified fields changed.
+func (c AppChange) Has(fields ...string) bool {
+	return slices.ContainsFunc(c.Fields, func(change FieldChange) bool {
+		return slices.Contains(fields, change.Field)
+	})
+}
+
+// DiffApps returns the differences between the apps in a and b, keyed by their
+// app IDs, such as between yesterday's and today's app inventories of a
+// device, or between the inventories of two devices. Added apps are reported
+// in the order of b, removed apps in the order of a, and changed apps again in
+// the order of b. Only the fields listed in DiffedAppFields are compared.
+//
+// If the same app ID appears multiple times in an inventory, the last one wins.
+func DiffApps(a, b []App) AppsDiff {
+	var diff AppsDiff
+	beforeApps := appsByID(a)
+	afterApps := appsByID(b)
+	seen := map[string]struct{}{} // report each app at most once.
+	for _, app := range a {
+		if _, ok := seen[app.Id]; ok {
+			continue
+		}
+		seen[app.Id] = struct{}{}
+		if _, ok := afterApps[app.Id]; !ok {
+			diff.Removed = append(diff.Removed, *beforeApps[app.Id])
+		}
+	}
+	clear(seen)
+	for _, app := range b {
+		if _, ok := seen[app.Id]; ok {
+			continue
+		}
+		seen[app.Id] = struct{}{}
+		after := afterApps[app.Id]
+		before, ok := beforeApps[app.Id]
+		if !ok {
+			diff.Added = append(diff.Added, *after)
+			continue
+		}
+		if fields := diffAppFields(before, after); len(fields) > 0 {
+			diff.Changed = append(diff.Changed, AppChange{
+				Before: *before,
+				After:  *after,
+				Fields: fields,
+			})
+		}
+	}
+	return diff
+}
+
+// diffAppFields returns the changes in the DiffedAppFields between the two
+// apps.
+func diffAppFields(before, after *App) []FieldChange {
+	var changes []FieldChange
+	beforeV := reflect.ValueOf(before).Elem()
+	afterV := reflect.ValueOf(after).Elem()
+	for idx, field := range diffedAppFields {
+		b := beforeV.FieldByIndex(diffedAppFieldIndices[idx]).Interface()
+		a := afterV.FieldByIndex(diffedAppFieldIndices[idx]).Interface()
+		if !reflect.DeepEqual(a, b) {
+			changes = append(changes, FieldChange{Field: field, Before: b, After: a})
+		}
+	}
+	return changes
+}
+
+// appsByID returns a map of app IDs to apps. If the same app ID appears
+// multiple times, the last one wins.
+func appsByID(apps []App) map[string]*App {
+	m := make(map[string]*App, len(apps))
+	for idx := range apps {
+		m[apps[idx].Id] = &apps[idx]
+	}
+	return m
+}
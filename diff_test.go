@@ -0,0 +1,66 @@
+// (c) Siemens AG 2026
+//
+// SPDX-License-Identifier: MIT
+
+package ieddata
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("diffing app inventories", func() {
+
+	It("reports no differences for identical inventories", func() {
+		apps := []App{{Id: "a", Version: "1"}, {Id: "b", Title: "B"}}
+		Expect(DiffApps(apps, apps).IsEmpty()).To(BeTrue())
+		Expect(DiffApps(nil, nil).IsEmpty()).To(BeTrue())
+	})
+
+	It("reports added, removed, and changed apps", func() {
+		a := []App{
+			{Id: "a", Version: "1.0.0", RedirectUrl: "foo/"},
+			{Id: "b"},
+			{Id: "c", AppStatus: 1, Title: "C"},
+			{Id: "e", Description: "old"},
+		}
+		b := []App{
+			{Id: "d"},
+			{Id: "a", Version: "1.1.0", RedirectUrl: "bar/"},
+			{Id: "c", AppStatus: 2, Title: "C"},
+			{Id: "e", Description: "new"},
+		}
+		diff := DiffApps(a, b)
+		Expect(diff.IsEmpty()).To(BeFalse())
+		Expect(diff.Added).To(HaveExactElements(HaveField("Id", "d")))
+		Expect(diff.Removed).To(HaveExactElements(HaveField("Id", "b")))
+		Expect(diff.Changed).To(HaveExactElements(
+			And(HaveField("After.Id", "a"),
+				HaveField("Fields", HaveExactElements(
+					FieldChange{Field: "Version", Before: "1.0.0", After: "1.1.0"},
+					FieldChange{Field: "RedirectUrl", Before: "foo/", After: "bar/"},
+				))),
+			And(HaveField("After.Id", "c"),
+				HaveField("Fields", HaveExactElements(
+					FieldChange{Field: "AppStatus", Before: 1, After: 2},
+				))),
+		))
+		Expect(diff.Changed[0].Has("Version")).To(BeTrue())
+		Expect(diff.Changed[0].Has("AppStatus", "IsVisible")).To(BeFalse())
+	})
+
+	It("reports duplicate app IDs only once", func() {
+		diff := DiffApps([]App{{Id: "a"}, {Id: "a"}}, []App{{Id: "b"}, {Id: "b"}})
+		Expect(diff.Added).To(HaveLen(1))
+		Expect(diff.Removed).To(HaveLen(1))
+	})
+
+	It("returns a copy of the diffed fields", func() {
+		fields := DiffedAppFields()
+		Expect(fields).To(ContainElement("Version"))
+		fields[0] = "Foo"
+		Expect(DiffedAppFields()).NotTo(ContainElement("Foo"))
+		Expect(diffedAppFieldIndices).To(HaveLen(len(fields)))
+	})
+
+})
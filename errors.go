@@ -0,0 +1,93 @@
+// (c) Siemens AG 2026
+//
+// SPDX-License-Identifier: MIT
+
+package ieddata
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// The distinct failure modes of locating, opening and querying app engine
+// databases. Errors returned by this package wrap these sentinel errors, so
+// callers can branch on them using errors.Is, while the error messages still
+// carry the details of the underlying cause.
+var (
+	// ErrDockerUnavailable signals that neither the Docker engine nor any other
+	// configured container engine for locating the IE runtime container could
+	// be contacted.
+	ErrDockerUnavailable = errors.New("docker engine unavailable")
+	// ErrNoRuntimeContainer signals that there is no IE runtime container.
+	ErrNoRuntimeContainer = errors.New("no Industrial Edge runtime container present")
+	// ErrDatabaseNotFound signals that the requested app engine database does
+	// not exist.
+	ErrDatabaseNotFound = errors.New("app engine database not found")
+	// ErrNotADatabase signals that the requested app engine database isn't an
+	// SQLite database in the first place.
+	ErrNotADatabase = errors.New("not an SQLite database")
+	// ErrDatabaseTooLarge signals that an app engine database inside an
+	// archive exceeds the maximum size to extract.
+	ErrDatabaseTooLarge = errors.New("app engine database too large")
+	// ErrWrongDatabase signals that an SQLite database lacks the tables or
+	// information expected, such as when querying apps from an app engine
+	// database other than “platformbox.db”.
+	ErrWrongDatabase = errors.New("not the expected app engine database")
+	// ErrIncompatibleSchema signals that an app engine database lacks some of
+	// the columns read by this package.
+	ErrIncompatibleSchema = errors.New("incompatible app engine database schema")
+	// ErrInconsistentSnapshot signals that the copy of an app engine database
+	// failed SQLite's integrity check, such as when the database was
+	// continuously written to while copying it.
+	ErrInconsistentSnapshot = errors.New("inconsistent database snapshot")
+	// ErrDatabaseBusy signals that an app engine database was continuously
+	// written to, so that no stable copy of it could be taken.
+	ErrDatabaseBusy = errors.New("app engine database busy")
+	// ErrClosed signals that an app engine database has already been closed.
+	ErrClosed = errors.New("app engine database closed")
+	// ErrMalformedValue signals that a structured value stored in an app
+	// engine database column, such as JSON app metadata, cannot be parsed.
+	ErrMalformedValue = errors.New("malformed app engine database value")
+	// ErrIconTooLarge signals that an app icon exceeds MaxIconSize.
+	ErrIconTooLarge = errors.New("app icon too large")
+	// ErrComposeFileTooLarge signals that an app compose file exceeds
+	// MaxComposeFileSize.
+	ErrComposeFileTooLarge = errors.New("app compose file too large")
+	// ErrAppNotFound signals that there is no installed app matching a lookup.
+	// Lookups return an AppNotFoundError with the details, which matches
+	// ErrAppNotFound when using errors.Is.
+	ErrAppNotFound = errors.New("app not found")
+)
+
+// AppNotFoundError signals that there is no installed app with the specified
+// value in the specified database column, such as “appId” or
+// “repositoryName”.
+type AppNotFoundError struct {
+	Column string
+	Value  string
+}
+
+// Error returns a description of the failed app lookup.
+func (e *AppNotFoundError) Error() string {
+	return fmt.Sprintf("%s: no app with %s %q", ErrAppNotFound, e.Column, e.Value)
+}
+
+// Is returns true if the target is ErrAppNotFound.
+func (e *AppNotFoundError) Is(target error) bool {
+	return target == ErrAppNotFound
+}
+
+// sqliteHeader is the magic header string at the beginning of any SQLite 3
+// database file.
+const sqliteHeader = "SQLite format 3\x00"
+
+// wrongDatabaseError returns the specified query error additionally wrapping
+// ErrWrongDatabase if the query failed because of a missing table; otherwise,
+// it returns the error unchanged.
+func wrongDatabaseError(err error) error {
+	if err == nil || !strings.Contains(err.Error(), "no such table") {
+		return err
+	}
+	return fmt.Errorf("%w: %w", ErrWrongDatabase, err)
+}
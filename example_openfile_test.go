@@ -0,0 +1,31 @@
+// (c) Siemens AG 2026
+//
+// SPDX-License-Identifier: MIT
+
+package ieddata_test
+
+import (
+	"fmt"
+
+	"github.com/siemens/ieddata"
+)
+
+// Shows the device name from an app engine database that has been copied off
+// an IED, without the need for a running IE runtime.
+func Example_openOfflineDatabase() {
+	db, err := ieddata.OpenFile("tests/sqlite-alpine-appengine-db/test-apps-and-device.db")
+	if err != nil {
+		panic(err)
+	}
+	defer db.Close()
+
+	dev, err := db.Device()
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("device name: %s\nIED version: %d.%d.%d\n",
+		dev.DeviceName, dev.IEDVersion.Major, dev.IEDVersion.Minor, dev.IEDVersion.Patch)
+	// Output: device name: iedx12345
+	// IED version: 1.3.1
+}
@@ -0,0 +1,147 @@
+// (c) Siemens AG 2026
+//
+// SPDX-License-Identifier: MIT
+
+package ieddata
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/thediveo/procfsroot"
+)
+
+// DefaultIconBaseDir is the directory inside the IE runtime container that app
+// icon URLs of the form “https://…/device/edge/…” refer to by default.
+const DefaultIconBaseDir = "/data"
+
+// iconURLPathPrefix is the URL path prefix under which the IE runtime serves
+// app icons (and other cached app data).
+const iconURLPathPrefix = "/device/edge/"
+
+// MaxIconSize is the maximum size in bytes of the app icons read by AppIcon.
+const MaxIconSize = 1 << 20
+
+// AppIcon returns the content type and contents of the icon of the specified
+// app, reading the icon from inside the IE runtime container. The app's
+// IconPath might either be a URL as served by the IE runtime, such as
+// “https://localhost:443/device/edge/BoxCache/app/…”, or a plain file path
+// inside the IE runtime container. URL paths are located relative to the
+// directory specified using WithIconBaseDir.
+//
+// As icon file names often lack a proper extension, AppIcon sniffs the
+// content type from the icon contents. AppIcon returns an error if the icon
+// isn't an image, and an error wrapping ErrIconTooLarge if the icon exceeds
+// MaxIconSize. Similar to ComposeFile, AppIcon only works for databases opened
+// from an IE runtime container.
+func (db *AppEngineDB) AppIcon(app App) (contentType string, data []byte, err error) {
+	return db.AppIconContext(context.Background(), app)
+}
+
+// AppIconContext works like AppIcon, but additionally honors cancellation and
+// deadlines of the specified context while reading the icon.
+func (db *AppEngineDB) AppIconContext(ctx context.Context, app App) (contentType string, data []byte, err error) {
+	if db.pid == 0 {
+		return "", nil, fmt.Errorf("%w: database not opened from inside an IE runtime container",
+			ErrNoRuntimeContainer)
+	}
+	iconPath, err := db.iconPath(app.IconPath)
+	if err != nil {
+		return "", nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return "", nil, err
+	}
+	rootpath := fmt.Sprintf("/proc/%d/root", db.pid)
+	iconPath, err = procfsroot.EvalSymlinks(iconPath, rootpath, procfsroot.EvalFullPath)
+	if err != nil {
+		return "", nil, fmt.Errorf("cannot determine full icon path, reason: %w", err)
+	}
+	f, err := os.Open(path.Join(rootpath, iconPath))
+	if err != nil {
+		return "", nil, fmt.Errorf("cannot read icon, reason: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	data, err = io.ReadAll(io.LimitReader(&contextReader{ctx: ctx, r: f}, MaxIconSize+1))
+	if err != nil {
+		return "", nil, fmt.Errorf("cannot read icon, reason: %w", err)
+	}
+	if len(data) > MaxIconSize {
+		return "", nil, fmt.Errorf("%w: icon %q exceeds %d bytes", ErrIconTooLarge, app.IconPath, MaxIconSize)
+	}
+	contentType = sniffIconContentType(data)
+	if !strings.HasPrefix(contentType, "image/") {
+		return "", nil, fmt.Errorf("icon %q is not an image, but %s", app.IconPath, contentType)
+	}
+	return contentType, data, nil
+}
+
+// iconPath returns the file path inside the IE runtime container of the
+// specified icon URL or path.
+func (db *AppEngineDB) iconPath(icon string) (string, error) {
+	if icon == "" {
+		return "", fmt.Errorf("app lacks an icon")
+	}
+	u, err := url.Parse(icon)
+	if err != nil {
+		return "", fmt.Errorf("invalid icon URL %q, reason: %w", icon, err)
+	}
+	if u.Scheme == "" {
+		return icon, nil
+	}
+	if !strings.HasPrefix(u.Path, iconURLPathPrefix) {
+		return "", fmt.Errorf("icon URL %q outside %s", icon, iconURLPathPrefix)
+	}
+	// path.Join cleans the path, so "../" elements cannot escape the icon
+	// base directory.
+	return path.Join(db.iconBaseDir,
+		path.Join("/", strings.TrimPrefix(u.Path, iconURLPathPrefix))), nil
+}
+
+// sniffIconContentType returns the content type of the specified icon data.
+// In contrast to http.DetectContentType, it detects SVG images.
+func sniffIconContentType(data []byte) string {
+	contentType := http.DetectContentType(data)
+	if strings.HasPrefix(contentType, "text/") && isSVG(data) {
+		return "image/svg+xml"
+	}
+	return contentType
+}
+
+// isSVG returns true if the specified data looks like an SVG image, that is,
+// has an “<svg” root element, optionally preceded by an XML declaration,
+// comments, and a document type declaration.
+func isSVG(data []byte) bool {
+	data = data[:min(len(data), 512)]
+	for {
+		data = bytes.TrimLeft(data, " \t\r\n\ufeff")
+		switch {
+		case bytes.HasPrefix(data, []byte("<svg")):
+			return true
+		case bytes.HasPrefix(data, []byte("<?")),
+			bytes.HasPrefix(data, []byte("<!")):
+			end := bytes.IndexByte(data, '>')
+			if end < 0 {
+				return false
+			}
+			data = data[end+1:]
+		default:
+			return false
+		}
+	}
+}
+
+// IconDataURI returns a “data:” URI with the specified content type and
+// base64-encoded data, such as for embedding an app icon returned by AppIcon
+// into HTML or JSON.
+func IconDataURI(contentType string, data []byte) string {
+	return "data:" + contentType + ";base64," + base64.StdEncoding.EncodeToString(data)
+}
@@ -0,0 +1,109 @@
+// (c) Siemens AG 2026
+//
+// SPDX-License-Identifier: MIT
+
+package ieddata
+
+import (
+	"bytes"
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/thediveo/lxkns/model"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/thediveo/success"
+)
+
+var testPNG = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\x0dIHDR")
+
+var _ = Describe("app icons", func() {
+
+	var iconBaseDir string
+	var db *AppEngineDB
+
+	BeforeEach(func(ctx context.Context) {
+		iconBaseDir = GinkgoT().TempDir()
+		cwd := Successful(os.Getwd())
+		db = Successful(open(ctx,
+			filepath.Join(cwd, "tests/sqlite-alpine-appengine-db/test-apps-and-device.db"),
+			model.PIDType(os.Getpid()),
+			WithIconBaseDir(iconBaseDir)))
+		DeferCleanup(func() { _ = db.Close() })
+	})
+
+	// writeIcon writes the icon data to the specified path relative to the
+	// icon base directory.
+	writeIcon := func(name string, data []byte) {
+		GinkgoHelper()
+		iconPath := filepath.Join(iconBaseDir, name)
+		Expect(os.MkdirAll(filepath.Dir(iconPath), 0700)).To(Succeed())
+		Expect(os.WriteFile(iconPath, data, 0600)).To(Succeed())
+	}
+
+	It("reads app icons from icon URLs", func(ctx context.Context) {
+		app := Successful(db.AppByRepositoryNameContext(ctx, "ccc"))
+		writeIcon("BoxCache/app/2d63b067597043439b208a86b411042d/cb2a31e374894f40b5db67f6d7546ec1.png", testPNG)
+
+		contentType, data, err := db.AppIcon(*app)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(contentType).To(Equal("image/png"))
+		Expect(data).To(Equal(testPNG))
+		Expect(IconDataURI(contentType, data)).To(Equal("data:image/png;base64,iVBORw0KGgoAAAANSUhEUg=="))
+	})
+
+	It("reads SVG icons from plain paths", func() {
+		svg := []byte(`<?xml version="1.0"?>
+<!-- icon -->
+<svg xmlns="http://www.w3.org/2000/svg"/>`)
+		writeIcon("icon.svg", svg)
+		contentType, data, err := db.AppIcon(App{IconPath: filepath.Join(iconBaseDir, "icon.svg")})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(contentType).To(Equal("image/svg+xml"))
+		Expect(data).To(Equal(svg))
+	})
+
+	It("rejects unsuitable icons", func() {
+		Expect(db.AppIcon(App{})).Error().To(MatchError(ContainSubstring("lacks an icon")))
+		Expect(db.AppIcon(App{IconPath: "https://localhost/foo/bar.png"})).Error().
+			To(MatchError(ContainSubstring("outside /device/edge/")))
+		Expect(db.AppIcon(App{IconPath: "https://localhost/device/edge/../../etc/passwd"})).Error().
+			To(MatchError(fs.ErrNotExist))
+		Expect(db.AppIcon(App{IconPath: "https://localhost/device/edge/missing.png"})).Error().
+			To(MatchError(fs.ErrNotExist))
+
+		writeIcon("text.png", []byte("hello, world"))
+		Expect(db.AppIcon(App{IconPath: "https://localhost/device/edge/text.png"})).Error().
+			To(MatchError(ContainSubstring("not an image")))
+
+		writeIcon("large.png", append(testPNG, bytes.Repeat([]byte{0}, MaxIconSize)...))
+		Expect(db.AppIcon(App{IconPath: "https://localhost/device/edge/large.png"})).Error().
+			To(MatchError(ErrIconTooLarge))
+	})
+
+	It("doesn't read icons when the context is already done", func(ctx context.Context) {
+		app := Successful(db.AppByRepositoryNameContext(ctx, "ccc"))
+		writeIcon("BoxCache/app/2d63b067597043439b208a86b411042d/cb2a31e374894f40b5db67f6d7546ec1.png", testPNG)
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+		_, _, err := db.AppIconContext(ctx, *app)
+		Expect(err).To(MatchError(context.Canceled))
+	})
+
+	It("needs an IE runtime container", func(ctx context.Context) {
+		db := Successful(OpenFileContext(ctx, "tests/sqlite-alpine-appengine-db/test-apps-and-device.db"))
+		defer func() { _ = db.Close() }()
+		Expect(db.AppIcon(App{IconPath: "/foo.png"})).Error().To(MatchError(ErrNoRuntimeContainer))
+	})
+
+	It("sniffs SVG images", func() {
+		Expect(isSVG([]byte("\ufeff  <svg/>"))).To(BeTrue())
+		Expect(isSVG([]byte("<!DOCTYPE svg><svg/>"))).To(BeTrue())
+		Expect(isSVG([]byte("<html/>"))).To(BeFalse())
+		Expect(isSVG([]byte("<?xml"))).To(BeFalse())
+	})
+
+})
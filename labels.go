@@ -0,0 +1,112 @@
+// (c) Siemens AG 2026
+//
+// SPDX-License-Identifier: MIT
+
+package ieddata
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ServiceLabel describes how the IE runtime's reverse proxy exposes a
+// particular service of an app, as stored in the serviceLabels column of the
+// applicationversions table.
+type ServiceLabel struct {
+	Name          string `json:"name"`     // name of the service.
+	Protocol      string `json:"protocol"` // such as “HTTP”.
+	Port          string `json:"port"`     // service port inside the container, such as “5000”.
+	Headers       string `json:"headers"`
+	RewriteTarget string `json:"rewriteTarget"` // such as “/”.
+}
+
+// ParsedServiceLabels returns the service labels of the app version, keyed by
+// the service names of the app's compose project. If the app version has no
+// service labels, ParsedServiceLabels returns nil without an error.
+// ParsedServiceLabels returns an error wrapping ErrMalformedValue if the
+// service labels cannot be parsed.
+func (a App) ParsedServiceLabels() (map[string][]ServiceLabel, error) {
+	return parseServiceLabels(a.ServiceLabels)
+}
+
+// ParsedServiceLabels returns the service labels of the app version; please
+// see App.ParsedServiceLabels for details.
+func (v AppVersion) ParsedServiceLabels() (map[string][]ServiceLabel, error) {
+	return parseServiceLabels(v.ServiceLabels)
+}
+
+// parseServiceLabels parses the specified service labels. On IEDs, the
+// service labels are a JSON object with the service names as keys and JSON
+// strings as values, with each string in turn containing a JSON array of
+// label objects. For robustness, the label arrays are also accepted without
+// this additional JSON string encoding.
+func parseServiceLabels(labels string) (map[string][]ServiceLabel, error) {
+	labels = strings.TrimSpace(labels)
+	if labels == "" || labels == "null" {
+		return nil, nil
+	}
+	var services map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(labels), &services); err != nil {
+		return nil, fmt.Errorf("%w: invalid service labels, reason: %w", ErrMalformedValue, err)
+	}
+	if services == nil {
+		return nil, nil
+	}
+	serviceLabels := make(map[string][]ServiceLabel, len(services))
+	for service, raw := range services {
+		var encoded string
+		if err := json.Unmarshal(raw, &encoded); err == nil {
+			raw = json.RawMessage(encoded)
+		}
+		var serviceLabel []ServiceLabel
+		if err := json.Unmarshal(raw, &serviceLabel); err != nil {
+			return nil, fmt.Errorf("%w: invalid labels of service %q, reason: %w",
+				ErrMalformedValue, service, err)
+		}
+		serviceLabels[service] = serviceLabel
+	}
+	return serviceLabels, nil
+}
+
+// ParsedToExecuteOrder returns the service names of the app's compose project
+// in the order the IE runtime starts them. If the app version doesn't specify
+// an order, ParsedToExecuteOrder returns nil without an error. The order can
+// be either a JSON array of service names or a comma-separated list of service
+// names. ParsedToExecuteOrder returns an error wrapping ErrMalformedValue if
+// the order is a malformed JSON array.
+func (a App) ParsedToExecuteOrder() ([]string, error) {
+	return parseToExecuteOrder(a.ToExecuteOrder)
+}
+
+// ParsedToExecuteOrder returns the service start order of the app version;
+// please see App.ParsedToExecuteOrder for details.
+func (v AppVersion) ParsedToExecuteOrder() ([]string, error) {
+	return parseToExecuteOrder(v.ToExecuteOrder)
+}
+
+// parseToExecuteOrder parses the specified service start order.
+func parseToExecuteOrder(order string) ([]string, error) {
+	order = strings.TrimSpace(order)
+	if order == "" || order == "null" {
+		return nil, nil
+	}
+	if strings.HasPrefix(order, "[") {
+		var services []string
+		if err := json.Unmarshal([]byte(order), &services); err != nil {
+			return nil, fmt.Errorf("%w: invalid service execution order, reason: %w",
+				ErrMalformedValue, err)
+		}
+		if len(services) == 0 {
+			return nil, nil
+		}
+		return services, nil
+	}
+	var services []string
+	for service := range strings.SplitSeq(order, ",") {
+		if service = strings.TrimSpace(service); service != "" {
+			services = append(services, service)
+		}
+	}
+	return services, nil
+}
@@ -0,0 +1,58 @@
+// (c) Siemens AG 2026
+//
+// SPDX-License-Identifier: MIT
+
+package ieddata
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/thediveo/success"
+)
+
+var _ = Describe("service labels and execution order", func() {
+
+	It("parses service labels", func(ctx context.Context) {
+		db := Successful(OpenFileContext(ctx, "tests/sqlite-alpine-appengine-db/test-apps-and-device.db"))
+		defer func() { _ = db.Close() }()
+
+		appA := Successful(db.AppByRepositoryNameContext(ctx, "aaa"))
+		Expect(appA.ParsedServiceLabels()).To(Equal(map[string][]ServiceLabel{
+			"aaa": {{Name: "aaa", Protocol: "HTTP", Port: "5000", RewriteTarget: "/"}},
+		}))
+		appB := Successful(db.AppByRepositoryNameContext(ctx, "bbb"))
+		Expect(appB.ParsedServiceLabels()).To(BeNil())
+
+		versions := Successful(db.AppVersionsContext(ctx, appA.Id))
+		Expect(versions[0].ParsedServiceLabels()).To(HaveKey("aaa"))
+	})
+
+	It("accepts service labels without nested encoding", func() {
+		Expect(App{ServiceLabels: `{"foo":[{"name":"foo","port":"80"}],"bar":[]}`}.ParsedServiceLabels()).
+			To(Equal(map[string][]ServiceLabel{
+				"foo": {{Name: "foo", Port: "80"}},
+				"bar": {},
+			}))
+	})
+
+	It("reports malformed service labels", func() {
+		for _, labels := range []string{`{"foo":`, `["foo"]`, `{"foo":"[{"}`, `{"foo":42}`} {
+			Expect(App{ServiceLabels: labels}.ParsedServiceLabels()).Error().
+				To(MatchError(ErrMalformedValue), "labels %s", labels)
+		}
+	})
+
+	It("parses the execution order", func() {
+		Expect(App{}.ParsedToExecuteOrder()).To(BeNil())
+		Expect(AppVersion{ToExecuteOrder: "[]"}.ParsedToExecuteOrder()).To(BeNil())
+		Expect(App{ToExecuteOrder: `["db", "web"]`}.ParsedToExecuteOrder()).
+			To(HaveExactElements("db", "web"))
+		Expect(App{ToExecuteOrder: " db, web ,,proxy"}.ParsedToExecuteOrder()).
+			To(HaveExactElements("db", "web", "proxy"))
+		Expect(App{ToExecuteOrder: `["db",`}.ParsedToExecuteOrder()).Error().
+			To(MatchError(ErrMalformedValue))
+	})
+
+})
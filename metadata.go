@@ -0,0 +1,79 @@
+// (c) Siemens AG 2026
+//
+// SPDX-License-Identifier: MIT
+
+package ieddata
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// AppMetadata is the parsed JSON metadata of an app version, as stored in the
+// metadata column of the applicationversions table. As the IE runtime doesn't
+// document the metadata format, AppMetadata gives access to the top-level
+// metadata fields in generic form, and additionally allows decoding the
+// metadata into a caller-specific typed structure using Decode.
+type AppMetadata struct {
+	Raw    json.RawMessage // unparsed JSON metadata.
+	Fields map[string]any  // top-level metadata fields; numbers are json.Number.
+}
+
+// ParsedMetadata returns the parsed JSON metadata of the app version. If the
+// app version has no metadata, that is, the metadata is either empty or JSON
+// “null”, ParsedMetadata returns nil without an error. ParsedMetadata returns
+// an error wrapping ErrMalformedValue if the metadata isn't a JSON object.
+func (a App) ParsedMetadata() (*AppMetadata, error) {
+	return parseMetadata(a.Metadata)
+}
+
+// ParsedMetadata returns the parsed JSON metadata of the app version; please
+// see App.ParsedMetadata for details.
+func (v AppVersion) ParsedMetadata() (*AppMetadata, error) {
+	return parseMetadata(v.Metadata)
+}
+
+// parseMetadata parses the specified JSON metadata.
+func parseMetadata(metadata string) (*AppMetadata, error) {
+	metadata = strings.TrimSpace(metadata)
+	if metadata == "" || metadata == "null" {
+		return nil, nil
+	}
+	dec := json.NewDecoder(strings.NewReader(metadata))
+	dec.UseNumber()
+	var fields map[string]any
+	if err := dec.Decode(&fields); err != nil {
+		return nil, fmt.Errorf("%w: invalid app metadata, reason: %w", ErrMalformedValue, err)
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("%w: invalid app metadata, reason: trailing data after JSON object",
+			ErrMalformedValue)
+	}
+	if fields == nil {
+		return nil, nil
+	}
+	return &AppMetadata{
+		Raw:    json.RawMessage(metadata),
+		Fields: fields,
+	}, nil
+}
+
+// Decode decodes the metadata into the value pointed to by v, such as a
+// caller-specific struct with only the metadata fields of interest.
+func (m *AppMetadata) Decode(v any) error {
+	if err := json.Unmarshal(m.Raw, v); err != nil {
+		return fmt.Errorf("%w: cannot decode app metadata, reason: %w", ErrMalformedValue, err)
+	}
+	return nil
+}
+
+// StringField returns the value of the named top-level metadata field if it is
+// a JSON string; otherwise, it returns false.
+func (m *AppMetadata) StringField(name string) (string, bool) {
+	if m == nil {
+		return "", false
+	}
+	s, ok := m.Fields[name].(string)
+	return s, ok
+}
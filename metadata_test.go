@@ -0,0 +1,62 @@
+// (c) Siemens AG 2026
+//
+// SPDX-License-Identifier: MIT
+
+package ieddata
+
+import (
+	"context"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/thediveo/success"
+)
+
+var _ = Describe("app metadata", func() {
+
+	It("handles missing metadata", func(ctx context.Context) {
+		db := Successful(OpenFileContext(ctx, "tests/sqlite-alpine-appengine-db/test-apps-and-device.db"))
+		defer func() { _ = db.Close() }()
+		for _, app := range Successful(db.AppsContext(ctx)) {
+			Expect(app.ParsedMetadata()).To(BeNil())
+		}
+		Expect(App{Metadata: " "}.ParsedMetadata()).To(BeNil())
+		Expect(AppVersion{Metadata: "null"}.ParsedMetadata()).To(BeNil())
+	})
+
+	It("parses metadata", func() {
+		md := Successful(App{Metadata: `{"vendor": "ACME", "size": 42, "tags": ["a", "b"]}`}.ParsedMetadata())
+		Expect(md.Fields).To(HaveKeyWithValue("vendor", "ACME"))
+		Expect(md.Fields).To(HaveKeyWithValue("size", json.Number("42")))
+		vendor, ok := md.StringField("vendor")
+		Expect(ok).To(BeTrue())
+		Expect(vendor).To(Equal("ACME"))
+		_, ok = md.StringField("size")
+		Expect(ok).To(BeFalse())
+		var nomd *AppMetadata
+		_, ok = nomd.StringField("vendor")
+		Expect(ok).To(BeFalse())
+
+		var typed struct {
+			Vendor string   `json:"vendor"`
+			Tags   []string `json:"tags"`
+		}
+		Expect(md.Decode(&typed)).To(Succeed())
+		Expect(typed.Vendor).To(Equal("ACME"))
+		Expect(typed.Tags).To(ConsistOf("a", "b"))
+
+		var wrong struct {
+			Vendor int `json:"vendor"`
+		}
+		Expect(md.Decode(&wrong)).To(MatchError(ErrMalformedValue))
+	})
+
+	It("reports malformed metadata", func() {
+		for _, metadata := range []string{`{"foo":`, `[1, 2]`, `"foo"`, `{} {}`} {
+			Expect(App{Metadata: metadata}.ParsedMetadata()).Error().
+				To(MatchError(ErrMalformedValue), "metadata %s", metadata)
+		}
+	})
+
+})
@@ -0,0 +1,138 @@
+// (c) Siemens AG 2026
+//
+// SPDX-License-Identifier: MIT
+
+package ieddata
+
+import (
+	"github.com/thediveo/lxkns/model"
+)
+
+// DefaultDockerHost is the Docker API endpoint used by default in order to
+// locate the IE runtime container. Please note that this endpoint refers to the
+// Docker socket as seen from the initial mount namespace, so it works from
+// inside a container deployed with “pid:host”.
+const DefaultDockerHost = "unix:///proc/1/root/run/docker.sock"
+
+// Option configures how Open and OpenInPID locate the IE runtime container and
+// access its app engine database(s).
+type Option func(*options)
+
+// options collects the configurable parameters of opening an app engine
+// database; use newOptions to get the defaults with the specified options
+// applied.
+type options struct {
+	dockerHost        string        // Docker API endpoint
+	engines           []Engine      // explicitly configured container engines, if any
+	coreContainerName string        // name of the IE runtime container
+	dbBaseDir         string        // location of app engine DBs in the runtime container
+	tempDir           string        // where to place the temporary database copies
+	iconBaseDir       string        // location of icon URL paths in the runtime container
+	maxExtractSize    int64         // maximum total size of files extracted from archives
+	pid               model.PIDType // IE runtime container PID, if already known; otherwise 0
+}
+
+// newOptions returns the default options with the specified options applied
+// in the given order.
+func newOptions(opts []Option) *options {
+	o := &options{
+		dockerHost:        DefaultDockerHost,
+		coreContainerName: EdgeIotCoreContainerName,
+		dbBaseDir:         dbBaseDir,
+		iconBaseDir:       DefaultIconBaseDir,
+		maxExtractSize:    DefaultMaxArchiveExtractSize,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// engineList returns the container engines to query in turn for the IE runtime
+// container. Unless explicitly configured using WithEngines, these are the
+// Docker engine, followed by the containerd engine, and finally Podman, each at
+// their default API endpoints, except for the Docker API endpoint, which can be
+// changed using WithDockerSocket.
+func (o *options) engineList() []Engine {
+	if o.engines != nil {
+		return o.engines
+	}
+	return []Engine{
+		DockerEngine(o.dockerHost),
+		ContainerdEngine(DefaultContainerdAddress),
+		PodmanEngine(DefaultPodmanHost),
+	}
+}
+
+// WithDockerSocket specifies the Docker API endpoint to use for locating the IE
+// runtime container, such as “unix:///run/docker.sock”. It defaults to
+// DefaultDockerHost.
+func WithDockerSocket(host string) Option {
+	return func(o *options) {
+		o.dockerHost = host
+	}
+}
+
+// WithEngines specifies the container engines to query in the given order for
+// the IE runtime container, such as:
+//
+//	ieddata.Open(ieddata.PlatformBoxDb,
+//	    ieddata.WithEngines(ieddata.ContainerdEngine("/run/containerd/containerd.sock")))
+//
+// This option overrides the default engines and also WithDockerSocket.
+func WithEngines(engines ...Engine) Option {
+	return func(o *options) {
+		o.engines = append([]Engine{}, engines...)
+	}
+}
+
+// WithCoreContainerName specifies the name of the IE runtime container to look
+// for; it defaults to EdgeIotCoreContainerName.
+func WithCoreContainerName(name string) Option {
+	return func(o *options) {
+		o.coreContainerName = name
+	}
+}
+
+// WithDBBaseDir specifies the directory inside the IE runtime container where
+// the app engine databases are located. It defaults to “/data/app_engine/db”.
+func WithDBBaseDir(dir string) Option {
+	return func(o *options) {
+		o.dbBaseDir = dir
+	}
+}
+
+// WithIconBaseDir specifies the directory inside the IE runtime container that
+// app icon URLs of the form “https://…/device/edge/…” refer to. It defaults to
+// DefaultIconBaseDir.
+func WithIconBaseDir(dir string) Option {
+	return func(o *options) {
+		o.iconBaseDir = dir
+	}
+}
+
+// WithTempDir specifies the directory in which to place the temporary copies of
+// app engine databases. It defaults to the directory returned by os.TempDir.
+func WithTempDir(dir string) Option {
+	return func(o *options) {
+		o.tempDir = dir
+	}
+}
+
+// WithMaxArchiveExtractSize specifies the maximum total size of the database
+// and its side files that OpenArchive extracts from an archive. It defaults to
+// DefaultMaxArchiveExtractSize.
+func WithMaxArchiveExtractSize(size int64) Option {
+	return func(o *options) {
+		o.maxExtractSize = size
+	}
+}
+
+// WithPID specifies the PID of the IE runtime container, skipping the runtime
+// container discovery in Open. OpenInPID ignores this option in favor of its
+// explicit PID parameter.
+func WithPID(pid model.PIDType) Option {
+	return func(o *options) {
+		o.pid = pid
+	}
+}
@@ -0,0 +1,89 @@
+// (c) Siemens AG 2026
+//
+// SPDX-License-Identifier: MIT
+
+package ieddata
+
+import (
+	"context"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/thediveo/lxkns/model"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/thediveo/success"
+)
+
+var _ = Describe("open options", func() {
+
+	It("defaults to the IE runtime container via Docker", func() {
+		o := newOptions(nil)
+		Expect(o.dockerHost).To(Equal(DefaultDockerHost))
+		Expect(o.coreContainerName).To(Equal(EdgeIotCoreContainerName))
+		Expect(o.dbBaseDir).To(Equal(dbBaseDir))
+		Expect(o.tempDir).To(BeEmpty())
+		Expect(o.iconBaseDir).To(Equal(DefaultIconBaseDir))
+		Expect(o.maxExtractSize).To(Equal(int64(DefaultMaxArchiveExtractSize)))
+		Expect(o.pid).To(BeZero())
+		Expect(o.engineList()).To(HaveExactElements(
+			And(HaveField("Name", "docker"), HaveField("Address", DefaultDockerHost)),
+			And(HaveField("Name", "containerd"), HaveField("Address", DefaultContainerdAddress)),
+			And(HaveField("Name", "podman"), HaveField("Address", DefaultPodmanHost)),
+		))
+	})
+
+	It("configures the container engines", func() {
+		o := newOptions([]Option{WithDockerSocket("unix:///run/docker.sock")})
+		Expect(o.engineList()).To(ContainElement(
+			And(HaveField("Name", "docker"), HaveField("Address", "unix:///run/docker.sock"))))
+
+		o = newOptions([]Option{
+			WithDockerSocket("unix:///run/docker.sock"),
+			WithEngines(ContainerdEngine("/run/containerd/containerd.sock")),
+		})
+		Expect(o.engineList()).To(HaveExactElements(
+			And(HaveField("Name", "containerd"), HaveField("Address", "/run/containerd/containerd.sock"))))
+	})
+
+	It("applies options", func() {
+		o := newOptions([]Option{
+			WithDockerSocket("unix:///run/docker.sock"),
+			WithCoreContainerName("foo-core"),
+			WithDBBaseDir("/foo/db"),
+			WithTempDir("/tmp/foo"),
+			WithIconBaseDir("/foo/icons"),
+			WithMaxArchiveExtractSize(42 << 20),
+			WithPID(42),
+		})
+		Expect(o.dockerHost).To(Equal("unix:///run/docker.sock"))
+		Expect(o.coreContainerName).To(Equal("foo-core"))
+		Expect(o.dbBaseDir).To(Equal("/foo/db"))
+		Expect(o.tempDir).To(Equal("/tmp/foo"))
+		Expect(o.iconBaseDir).To(Equal("/foo/icons"))
+		Expect(o.maxExtractSize).To(Equal(int64(42 << 20)))
+		Expect(o.pid).To(Equal(model.PIDType(42)))
+	})
+
+	It("places the temporary database copy into the specified directory", func() {
+		tmpdir := GinkgoT().TempDir()
+		cwd := Successful(os.Getwd())
+		db := Successful(open(context.Background(), path.Join(cwd, "tests/sqlite-alpine-appengine-db/test-apps-and-device.db"),
+			model.PIDType(os.Getpid()), WithTempDir(tmpdir)))
+		defer func() { _ = db.Close() }()
+		Expect(filepath.Dir(db.snaps.snapshot().dir)).To(Equal(tmpdir))
+		Expect(db.Apps()).To(HaveLen(4))
+	})
+
+	It("opens in a specified directory of a specified PID", func() {
+		cwd := Successful(os.Getwd())
+		db := Successful(Open("test-apps-and-device.db",
+			WithPID(model.PIDType(os.Getpid())),
+			WithDBBaseDir(path.Join(cwd, "tests/sqlite-alpine-appengine-db"))))
+		defer func() { _ = db.Close() }()
+		Expect(db.Apps()).To(HaveLen(4))
+	})
+
+})
@@ -22,6 +22,7 @@ import (
 
 var sess *morbyd.Session
 var fakecore *morbyd.Container
+var fakeapp *morbyd.Container
 
 var _ = BeforeSuite(func(ctx context.Context) {
 	if os.Getuid() != 0 {
@@ -53,6 +54,16 @@ var _ = BeforeSuite(func(ctx context.Context) {
 	})
 	Expect(fmt.Sprintf("/proc/%d/root/%s/%s",
 		Successful(fakecore.PID(ctx)), dbBaseDir, PlatformBoxDb)).To(BeAnExistingFile())
+
+	By("deploying a fake app container belonging to AppB")
+	fakeapp = Successful(sess.Run(ctx,
+		imgref,
+		run.WithName("bbb-foo-1"),
+		run.WithLabel("com.docker.compose.project=bbb"),
+		run.WithLabel(composeServiceLabel+"=foo")))
+	DeferCleanup(func(ctx context.Context) {
+		fakeapp.Kill(ctx)
+	})
 })
 
 func TestIEDData(t *testing.T) {
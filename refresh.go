@@ -0,0 +1,60 @@
+// (c) Siemens AG 2026
+//
+// SPDX-License-Identifier: MIT
+
+package ieddata
+
+import (
+	"context"
+	"fmt"
+)
+
+// Refresh updates the snapshot of the app engine database if the original
+// database has changed since the snapshot was taken, as detected by the size
+// and modification time of the original database file and its side files.
+// Refresh reports whether it updated the snapshot.
+//
+// Refresh first takes a new snapshot behind the scenes and only when this new
+// snapshot checks out fine, it switches over to the new snapshot. In case of
+// errors, the AppEngineDB keeps using the old snapshot. Databases without an
+// original database file to refresh from never change.
+//
+// Queries, transactions, and iterations still in progress on the old snapshot
+// are allowed to finish undisturbed; the old snapshot gets disposed of only
+// after its last database connection has been closed.
+func (db *AppEngineDB) Refresh(ctx context.Context) (bool, error) {
+	db.refreshMu.Lock()
+	defer db.refreshMu.Unlock()
+
+	if db.snaps == nil {
+		return false, nil
+	}
+	oldSnap := db.snaps.snapshot()
+	if oldSnap == nil {
+		return false, ErrClosed
+	}
+	if db.sourcePath == "" {
+		return false, nil
+	}
+
+	source, err := statSource(db.sourcePath)
+	if err != nil {
+		return false, fmt.Errorf("cannot refresh database, reason: %w", err)
+	}
+	if source.equal(oldSnap.source) {
+		return false, nil
+	}
+	snap, err := takeSnapshot(ctx, db.sourcePath, db.tempDir)
+	if err != nil {
+		return false, err
+	}
+	if err := snap.verify(ctx); err != nil {
+		snap.remove()
+		return false, err
+	}
+	if err := db.snaps.swap(snap); err != nil {
+		snap.remove()
+		return false, err
+	}
+	return true, nil
+}
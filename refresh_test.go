@@ -0,0 +1,119 @@
+// (c) Siemens AG 2026
+//
+// SPDX-License-Identifier: MIT
+
+package ieddata
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/thediveo/lxkns/model"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/thediveo/success"
+)
+
+var _ = Describe("refreshing database snapshots", func() {
+
+	It("refreshes only after changes", func(ctx context.Context) {
+		srcdir := GinkgoT().TempDir()
+		srcdb := newTestDB(srcdir, 1)
+		defer func() { _ = srcdb.Close() }()
+
+		db := Successful(open(ctx, filepath.Join(srcdir, "test.db"), model.PIDType(os.Getpid())))
+		defer func() { _ = db.Close() }()
+		oldSnapDir := db.snaps.snapshot().dir
+
+		count := func() (n int) {
+			GinkgoHelper()
+			Expect(db.DB.QueryRowContext(ctx, "SELECT COUNT(*) FROM foo").Scan(&n)).To(Succeed())
+			return
+		}
+		Expect(count()).To(Equal(1))
+
+		Expect(db.Refresh(ctx)).To(BeFalse())
+		Expect(db.snaps.snapshot().dir).To(Equal(oldSnapDir))
+
+		Expect(srcdb.Exec("INSERT INTO foo VALUES ('baz')")).Error().NotTo(HaveOccurred())
+		Expect(srcdb.Exec("CREATE TABLE bar (baz TEXT)")).Error().NotTo(HaveOccurred())
+		Expect(db.Refresh(ctx)).To(BeTrue())
+		Expect(count()).To(Equal(2))
+		Expect(oldSnapDir).NotTo(BeAnExistingFile())
+		Expect(db.Refresh(ctx)).To(BeFalse())
+	})
+
+	It("lets queries in progress finish", func(ctx context.Context) {
+		srcdir := GinkgoT().TempDir()
+		srcdb := newTestDB(srcdir, 10)
+		defer func() { _ = srcdb.Close() }()
+
+		db := Successful(open(ctx, filepath.Join(srcdir, "test.db"), model.PIDType(os.Getpid())))
+		defer func() { _ = db.Close() }()
+		oldSnapDir := db.snaps.snapshot().dir
+
+		rows := Successful(db.QueryContext(ctx, "SELECT bar FROM foo"))
+		Expect(rows.Next()).To(BeTrue())
+
+		Expect(srcdb.Exec("INSERT INTO foo VALUES ('baz')")).Error().NotTo(HaveOccurred())
+		Expect(db.Refresh(ctx)).To(BeTrue())
+		Expect(oldSnapDir).To(BeADirectory())
+
+		n := 1
+		for rows.Next() {
+			n++
+		}
+		Expect(rows.Err()).NotTo(HaveOccurred())
+		Expect(rows.Close()).To(Succeed())
+		Expect(n).To(Equal(10))
+		Expect(oldSnapDir).NotTo(BeAnExistingFile())
+
+		var count int
+		Expect(db.QueryRowContext(ctx, "SELECT COUNT(*) FROM foo").Scan(&count)).To(Succeed())
+		Expect(count).To(Equal(11))
+	})
+
+	It("refreshes concurrently to queries", func(ctx context.Context) {
+		srcdir := GinkgoT().TempDir()
+		srcdb := newTestDB(srcdir, 1)
+		defer func() { _ = srcdb.Close() }()
+
+		tmpdir := GinkgoT().TempDir()
+		db := Successful(open(ctx, filepath.Join(srcdir, "test.db"), model.PIDType(os.Getpid()),
+			WithTempDir(tmpdir)))
+
+		var wg sync.WaitGroup
+		for range 4 {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				for range 50 {
+					var count int
+					Expect(db.QueryRowContext(ctx, "SELECT COUNT(*) FROM foo").Scan(&count)).To(Succeed())
+				}
+			}()
+		}
+		for range 10 {
+			Expect(srcdb.Exec("INSERT INTO foo VALUES ('baz')")).Error().NotTo(HaveOccurred())
+			Expect(db.Refresh(ctx)).Error().NotTo(HaveOccurred())
+		}
+		wg.Wait()
+
+		Expect(db.Close()).To(Succeed())
+		Expect(os.ReadDir(tmpdir)).To(BeEmpty())
+	})
+
+	It("doesn't refresh closed databases", func(ctx context.Context) {
+		srcdir := GinkgoT().TempDir()
+		Expect(newTestDB(srcdir, 1).Close()).To(Succeed())
+
+		db := Successful(open(ctx, filepath.Join(srcdir, "test.db"), model.PIDType(os.Getpid())))
+		Expect(db.Close()).To(Succeed())
+		Expect(db.Refresh(ctx)).Error().To(MatchError(ErrClosed))
+	})
+
+})
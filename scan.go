@@ -0,0 +1,224 @@
+// (c) Siemens AG 2026
+//
+// SPDX-License-Identifier: MIT
+
+package ieddata
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"iter"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ScanAll runs the specified query and returns the resulting rows scanned into
+// a slice of struct type T. Result columns are mapped to exported fields of T
+// by their “db” tags, falling back to the FirstLower'ed field names. Fields
+// tagged `db:"-"` are ignored. Columns not matching any field are silently
+// skipped, so queries such as “SELECT *” don't break when a newer IE runtime
+// adds further columns.
+//
+// Fields of type time.Time accept not only the date/time values returned by
+// the SQLite driver for timestamp columns, but also Unix epoch seconds and
+// date/time strings, as the IE runtime doesn't always store timestamps
+// consistently. NULL values result in zero times.
+//
+// ScanAll works around modernc.org/sqlite not fully supporting sqlx's
+// StructScan. Pass an AppEngineDB's embedded sqlx.DB, or a transaction, as the
+// db parameter.
+func ScanAll[T any](db sqlx.Queryer, query string, args ...any) ([]T, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanAll[T](rows)
+}
+
+// ScanAllContext works like ScanAll, but additionally honors cancellation and
+// deadlines of the specified context while querying the database.
+func ScanAllContext[T any](ctx context.Context, db sqlx.QueryerContext, query string, args ...any) ([]T, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanAll[T](rows)
+}
+
+// scanAll scans all rows into a slice of T, closing the rows afterwards.
+func scanAll[T any](rows *sql.Rows) ([]T, error) {
+	elements := make([]T, 0)
+	for element, err := range scanRows[T](rows) {
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, element)
+	}
+	return elements, nil
+}
+
+// scanRows returns an iterator over the rows scanned into T. The iterator
+// yields at most one error, and then stops. The rows get closed when the
+// iteration finishes or stops early. Callers must thus always iterate at least
+// once over the returned iterator.
+func scanRows[T any](rows *sql.Rows) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		defer func() { _ = rows.Close() }()
+		var zero T
+		scanner, err := newRowScanner[T](rows)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		for rows.Next() {
+			element, err := scanner.scan(rows)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			if !yield(element, nil) {
+				return
+			}
+		}
+		if err := rows.Err(); err != nil {
+			yield(zero, err)
+		}
+	}
+}
+
+// rowScanner scans result rows into struct type T, based on a mapping of
+// result column indices to struct field indices.
+type rowScanner[T any] struct {
+	// Field indices for the query result columns, in column order. A field
+	// index < 0 indicates that the column does not match any T struct field.
+	columnFieldIndices []int
+}
+
+// newRowScanner returns a rowScanner for the columns of the specified rows.
+func newRowScanner[T any](rows *sql.Rows) (*rowScanner[T], error) {
+	// Work around cznic/sqlite not fully supporting sqlx for the moment. For
+	// this, we need to map the query result column to their T struct fields.
+	// In particular, we map column indices (instead of names) to field indices.
+	elemT := reflect.TypeFor[T]()
+	if elemT.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot scan rows into non-struct type %s", elemT)
+	}
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	s := &rowScanner[T]{columnFieldIndices: make([]int, len(cols))}
+	for columnIdx := range s.columnFieldIndices {
+		s.columnFieldIndices[columnIdx] = -1 // no corresponding field
+	}
+	for fieldIdx := range elemT.NumField() {
+		columnName := fieldColumnName(elemT.Field(fieldIdx))
+		if columnName == "" {
+			continue
+		}
+		columnIdx := slices.Index(cols, columnName)
+		if columnIdx < 0 {
+			continue
+		}
+		s.columnFieldIndices[columnIdx] = fieldIdx
+	}
+	return s, nil
+}
+
+// fieldColumnName returns the name of the column mapped to the specified struct
+// field, or "" if the field isn't mapped to any column.
+func fieldColumnName(field reflect.StructField) string {
+	if !field.IsExported() {
+		return ""
+	}
+	columnName := field.Tag.Get("db")
+	switch columnName {
+	case "-":
+		return ""
+	case "":
+		return FirstLower(field.Name)
+	}
+	return columnName
+}
+
+// structColumns returns the names of the columns mapped to the fields of
+// struct type T, in field order.
+func structColumns[T any]() []string {
+	elemT := reflect.TypeFor[T]()
+	var columns []string
+	for fieldIdx := range elemT.NumField() {
+		if columnName := fieldColumnName(elemT.Field(fieldIdx)); columnName != "" {
+			columns = append(columns, columnName)
+		}
+	}
+	return columns
+}
+
+// scan the current row into a new T.
+func (s *rowScanner[T]) scan(rows *sql.Rows) (T, error) {
+	var element T
+	elemV := reflect.ValueOf(&element).Elem()
+	values := make([]any, len(s.columnFieldIndices))
+	for columnIdx, fieldIdx := range s.columnFieldIndices {
+		if fieldIdx < 0 {
+			values[columnIdx] = new(any)
+			continue
+		}
+		field := elemV.Field(fieldIdx)
+		if field.Type() == timeT {
+			values[columnIdx] = &timestampScanner{t: field.Addr().Interface().(*time.Time)}
+			continue
+		}
+		values[columnIdx] = field.Addr().Interface()
+	}
+	if err := rows.Scan(values...); err != nil {
+		var zero T
+		return zero, err
+	}
+	return element, nil
+}
+
+var timeT = reflect.TypeFor[time.Time]()
+
+// timestampLayouts lists the layouts of date/time strings in timestamp columns,
+// in the order to try them.
+var timestampLayouts = []string{
+	time.DateTime,
+	time.RFC3339,
+	time.DateOnly,
+}
+
+// timestampScanner scans timestamp column values into a time.Time, accepting
+// date/time values, Unix epoch seconds, as well as date/time strings.
+type timestampScanner struct {
+	t *time.Time
+}
+
+// Scan implements the sql.Scanner interface.
+func (s *timestampScanner) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*s.t = time.Time{}
+	case time.Time:
+		*s.t = v
+	case int64:
+		*s.t = time.Unix(v, 0).UTC()
+	case []byte:
+		return s.Scan(string(v))
+	case string:
+		for _, layout := range timestampLayouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				*s.t = t
+				return nil
+			}
+		}
+		return fmt.Errorf("invalid date/time %q", v)
+	default:
+		return fmt.Errorf("unsupported date/time value of type %T", src)
+	}
+	return nil
+}
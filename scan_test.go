@@ -0,0 +1,80 @@
+// (c) Siemens AG 2026
+//
+// SPDX-License-Identifier: MIT
+
+package ieddata
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/thediveo/success"
+)
+
+var _ = Describe("scanning rows into structs", func() {
+
+	type deviceRow struct {
+		Key         string `db:"deviceKey"`
+		DeviceValue string
+		Ignored     string `db:"-"`
+		unexported  string //nolint:unused
+	}
+
+	var db *AppEngineDB
+
+	BeforeEach(func(ctx context.Context) {
+		db = Successful(OpenFileContext(ctx, "tests/sqlite-alpine-appengine-db/test-apps-and-device.db"))
+		DeferCleanup(func() { _ = db.Close() })
+	})
+
+	It("scans rows using db tags and field names", func(ctx context.Context) {
+		rows := Successful(ScanAllContext[deviceRow](ctx, db.DB,
+			"SELECT *, 'foo' AS ignored, 'bar' AS unexported FROM device WHERE deviceKey=?", "deviceName"))
+		Expect(rows).To(ConsistOf(deviceRow{Key: "deviceName", DeviceValue: "iedx12345"}))
+
+		Expect(ScanAll[deviceRow](db.DB, "SELECT * FROM device WHERE deviceKey='foo'")).
+			To(BeEmpty())
+	})
+
+	It("rejects non-struct types", func() {
+		Expect(ScanAll[string](db.DB, "SELECT * FROM device")).Error().
+			To(MatchError(ContainSubstring("non-struct type string")))
+	})
+
+	It("scans date/time values as the SQLite driver returns them", func(ctx context.Context) {
+		type timeRow struct {
+			Created time.Time
+		}
+		sqldb := Successful(sqlx.Open(dbDriverName, filepath.Join(GinkgoT().TempDir(), "time.db")))
+		defer func() { _ = sqldb.Close() }()
+		Expect(sqldb.Exec("CREATE TABLE foo (created DATETIME)")).Error().NotTo(HaveOccurred())
+		for _, value := range []string{"2021-10-20 12:45:01", "2021-10-20T12:45:01.5+02:00"} {
+			Expect(sqldb.Exec("INSERT INTO foo VALUES (?)", value)).Error().NotTo(HaveOccurred())
+		}
+
+		// Scan directly into time.Time values, as ScanAll did before
+		// additionally accepting Unix epoch seconds and date/time strings.
+		var want []timeRow
+		rows := Successful(sqldb.QueryContext(ctx, "SELECT created FROM foo"))
+		for rows.Next() {
+			var row timeRow
+			Expect(rows.Scan(&row.Created)).To(Succeed())
+			want = append(want, row)
+		}
+		Expect(rows.Err()).NotTo(HaveOccurred())
+		Expect(want).To(HaveLen(2))
+
+		Expect(ScanAllContext[timeRow](ctx, sqldb, "SELECT created FROM foo")).To(Equal(want))
+	})
+
+	It("reports query errors", func(ctx context.Context) {
+		Expect(ScanAllContext[deviceRow](ctx, db.DB, "SELECT * FROM nowhere")).Error().
+			To(MatchError(ContainSubstring("no such table")))
+	})
+
+})
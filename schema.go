@@ -0,0 +1,211 @@
+// (c) Siemens AG 2026
+//
+// SPDX-License-Identifier: MIT
+
+package ieddata
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Schema describes the tables of an app engine database, excluding SQLite's
+// internal tables.
+type Schema struct {
+	Tables []Table // tables, sorted by name.
+}
+
+// Table describes a single table of an app engine database.
+type Table struct {
+	Name    string
+	Columns []Column // columns, in table order.
+	Indices []Index  // indices, sorted by name.
+}
+
+// Column describes a single table column.
+type Column struct {
+	Name       string  `db:"name"`
+	Type       string  `db:"type"`       // declared type, such as “TEXT”.
+	NotNull    bool    `db:"notnull"`    // column has a NOT NULL constraint.
+	Default    *string `db:"dflt_value"` // default value expression, if any.
+	PrimaryKey int     `db:"pk"`         // 1-based index in the primary key, or 0.
+}
+
+// Index describes a single table index.
+type Index struct {
+	Name    string   `db:"name"`
+	Unique  bool     `db:"unique"`
+	Columns []string `db:"-"` // indexed columns, in index order.
+}
+
+// SchemaFingerprint identifies a particular database schema by its hash. As
+// this package doesn't know the schemas of the different IE runtime versions,
+// it leaves it to callers to keep track of the fingerprints they have already
+// seen. Use CheckSchema to detect schemas incompatible with this package.
+type SchemaFingerprint struct {
+	Hash string // hex-encoded SHA256 hash over the canonical schema.
+}
+
+// deviceColumns lists the columns of the device table read by DeviceInfo.
+var deviceColumns = []string{"deviceKey", "deviceValue"}
+
+// Schema returns the tables, including their columns and indices, of the app
+// engine database.
+func (db *AppEngineDB) Schema() (*Schema, error) {
+	return db.SchemaContext(context.Background())
+}
+
+// SchemaContext works like Schema, but additionally honors cancellation and
+// deadlines of the specified context while querying the database.
+func (db *AppEngineDB) SchemaContext(ctx context.Context) (*Schema, error) {
+	// Run all queries inside the same read transaction so that they see the
+	// same snapshot even when refreshing concurrently.
+	tx, err := db.DB.BeginTxx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var tableNames []string
+	if err := tx.SelectContext(ctx, &tableNames,
+		"SELECT name FROM sqlite_master WHERE type='table' AND name NOT LIKE 'sqlite\\_%' ESCAPE '\\' ORDER BY name"); err != nil {
+		return nil, fmt.Errorf("cannot query tables, reason: %w", err)
+	}
+	schema := &Schema{Tables: make([]Table, 0, len(tableNames))}
+	for _, tableName := range tableNames {
+		table, err := queryTable(ctx, tx, tableName)
+		if err != nil {
+			return nil, err
+		}
+		schema.Tables = append(schema.Tables, *table)
+	}
+	return schema, nil
+}
+
+// queryTable returns the columns and indices of the named table.
+func queryTable(ctx context.Context, tx *sqlx.Tx, tableName string) (*Table, error) {
+	table := &Table{Name: tableName}
+	if err := tx.SelectContext(ctx, &table.Columns,
+		`SELECT name, type, "notnull", dflt_value, pk FROM pragma_table_info(?) ORDER BY cid`,
+		tableName); err != nil {
+		return nil, fmt.Errorf("cannot query columns of table %q, reason: %w", tableName, err)
+	}
+	if err := tx.SelectContext(ctx, &table.Indices,
+		`SELECT name, "unique" FROM pragma_index_list(?) ORDER BY name`,
+		tableName); err != nil {
+		return nil, fmt.Errorf("cannot query indices of table %q, reason: %w", tableName, err)
+	}
+	for idx := range table.Indices {
+		index := &table.Indices[idx]
+		if err := tx.SelectContext(ctx, &index.Columns,
+			`SELECT name FROM pragma_index_info(?) ORDER BY seqno`,
+			index.Name); err != nil {
+			return nil, fmt.Errorf("cannot query columns of index %q, reason: %w", index.Name, err)
+		}
+	}
+	return table, nil
+}
+
+// Fingerprint returns the fingerprint of the schema. The fingerprint covers
+// table names, column names, types, constraints, and primary keys, as well as
+// indices. It doesn't cover column default values, as these don't change how
+// to query the database.
+func (s *Schema) Fingerprint() SchemaFingerprint {
+	var canon strings.Builder
+	for _, table := range s.Tables {
+		fmt.Fprintf(&canon, "table %s\n", table.Name)
+		for _, column := range table.Columns {
+			fmt.Fprintf(&canon, "column %s %s %t %d\n",
+				column.Name, strings.ToUpper(column.Type), column.NotNull, column.PrimaryKey)
+		}
+		for _, index := range table.Indices {
+			fmt.Fprintf(&canon, "index %s %t %s\n",
+				index.Name, index.Unique, strings.Join(index.Columns, ","))
+		}
+	}
+	hash := sha256.Sum256([]byte(canon.String()))
+	return SchemaFingerprint{Hash: hex.EncodeToString(hash[:])}
+}
+
+// MissingColumns returns the columns missing from the schema that are read
+// into App, AppVersion, and device information, in “table.column” notation.
+// App columns missing from both the application and applicationversions
+// tables are reported as application table columns.
+func (s *Schema) MissingColumns() []string {
+	tableColumns := map[string][]string{}
+	for _, table := range s.Tables {
+		for _, column := range table.Columns {
+			tableColumns[table.Name] = append(tableColumns[table.Name], column.Name)
+		}
+	}
+	var missing []string
+	versionColumns := structColumns[AppVersion]()
+	for _, column := range versionColumns {
+		if !slices.Contains(tableColumns["applicationversions"], column) {
+			missing = append(missing, "applicationversions."+column)
+		}
+	}
+	for _, column := range structColumns[App]() {
+		// The version creation date is an alias of the applicationversions'
+		// createdDate column, and the other applicationversions columns have
+		// already been checked above.
+		if column == "versionCreatedDate" || slices.Contains(versionColumns, column) {
+			continue
+		}
+		if !slices.Contains(tableColumns["application"], column) {
+			missing = append(missing, "application."+column)
+		}
+	}
+	for _, column := range deviceColumns {
+		if !slices.Contains(tableColumns["device"], column) {
+			missing = append(missing, "device."+column)
+		}
+	}
+	return missing
+}
+
+// SchemaFingerprint returns the fingerprint of the app engine database's
+// schema. Use SchemaFingerprint to detect devices running an IE runtime with
+// a database schema not seen before.
+func (db *AppEngineDB) SchemaFingerprint() (SchemaFingerprint, error) {
+	return db.SchemaFingerprintContext(context.Background())
+}
+
+// SchemaFingerprintContext works like SchemaFingerprint, but additionally
+// honors cancellation and deadlines of the specified context while querying
+// the database.
+func (db *AppEngineDB) SchemaFingerprintContext(ctx context.Context) (SchemaFingerprint, error) {
+	schema, err := db.SchemaContext(ctx)
+	if err != nil {
+		return SchemaFingerprint{}, err
+	}
+	return schema.Fingerprint(), nil
+}
+
+// CheckSchema checks that the app engine database has all the columns read
+// into App, AppVersion, and device information. Otherwise, it returns an error
+// wrapping ErrIncompatibleSchema that lists the missing columns.
+func (db *AppEngineDB) CheckSchema() error {
+	return db.CheckSchemaContext(context.Background())
+}
+
+// CheckSchemaContext works like CheckSchema, but additionally honors
+// cancellation and deadlines of the specified context while querying the
+// database.
+func (db *AppEngineDB) CheckSchemaContext(ctx context.Context) error {
+	schema, err := db.SchemaContext(ctx)
+	if err != nil {
+		return err
+	}
+	if missing := schema.MissingColumns(); len(missing) > 0 {
+		return fmt.Errorf("%w: missing columns %s", ErrIncompatibleSchema, strings.Join(missing, ", "))
+	}
+	return nil
+}
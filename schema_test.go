@@ -0,0 +1,110 @@
+// (c) Siemens AG 2026
+//
+// SPDX-License-Identifier: MIT
+
+package ieddata
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/jmoiron/sqlx"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/thediveo/success"
+)
+
+// testFixtureSchemaHash is the fingerprint hash of the trimmed-down schema of
+// the test apps and device database.
+const testFixtureSchemaHash = "00d79861f48b27613fbeccc2d8e4420773c1a0f5362b89ed802beb76b0914e1f"
+
+var _ = Describe("database schema", func() {
+
+	It("returns the schema of the app engine database", func(ctx context.Context) {
+		db := Successful(OpenFileContext(ctx, "tests/sqlite-alpine-appengine-db/test-apps-and-device.db"))
+		defer func() { _ = db.Close() }()
+
+		schema := Successful(db.SchemaContext(ctx))
+		Expect(schema.Tables).To(HaveExactElements(
+			HaveField("Name", "application"),
+			HaveField("Name", "applicationversions"),
+			HaveField("Name", "device"),
+		))
+		Expect(schema.Tables[0].Columns).To(ContainElement(And(
+			HaveField("Name", "appId"),
+			HaveField("PrimaryKey", 1))))
+		Expect(schema.Tables[2].Columns).To(ContainElements(
+			HaveField("Name", "deviceKey"),
+			HaveField("Name", "deviceValue")))
+
+		fingerprint := Successful(db.SchemaFingerprintContext(ctx))
+		Expect(fingerprint.Hash).To(Equal(testFixtureSchemaHash))
+		Expect(schema.MissingColumns()).To(BeEmpty())
+		Expect(db.CheckSchemaContext(ctx)).To(Succeed())
+	})
+
+	It("reports missing columns", func(ctx context.Context) {
+		dbpath := filepath.Join(GinkgoT().TempDir(), "incompatible.db")
+		Expect(os.WriteFile(dbpath,
+			Successful(os.ReadFile("tests/sqlite-alpine-appengine-db/test-apps-and-device.db")),
+			0600)).To(Succeed())
+		sqldb := Successful(sqlx.Open(dbDriverName, dbpath))
+		for _, stmt := range []string{
+			"ALTER TABLE application DROP COLUMN icon",
+			"ALTER TABLE applicationversions DROP COLUMN serviceLabels",
+			"DROP TABLE device",
+		} {
+			Expect(sqldb.Exec(stmt)).Error().NotTo(HaveOccurred(), stmt)
+		}
+		Expect(sqldb.Close()).To(Succeed())
+
+		db := Successful(OpenFileContext(ctx, dbpath))
+		defer func() { _ = db.Close() }()
+		Expect(Successful(db.Schema()).MissingColumns()).To(HaveExactElements(
+			"applicationversions.serviceLabels",
+			"application.icon",
+			"device.deviceKey",
+			"device.deviceValue",
+		))
+		Expect(db.CheckSchema()).To(SatisfyAll(
+			MatchError(ErrIncompatibleSchema),
+			MatchError(ContainSubstring("application.icon"))))
+	})
+
+	It("fingerprints unknown schemas", func(ctx context.Context) {
+		dbpath := filepath.Join(GinkgoT().TempDir(), "unknown.db")
+		sqldb := Successful(sqlx.Open(dbDriverName, dbpath))
+		Expect(sqldb.Exec("CREATE TABLE foo (bar TEXT NOT NULL DEFAULT 'baz', id INTEGER PRIMARY KEY)")).
+			Error().NotTo(HaveOccurred())
+		Expect(sqldb.Exec("CREATE UNIQUE INDEX foo_bar ON foo (bar, id)")).Error().NotTo(HaveOccurred())
+		Expect(sqldb.Close()).To(Succeed())
+
+		db := Successful(OpenFileContext(ctx, dbpath))
+		defer func() { _ = db.Close() }()
+		schema := Successful(db.Schema())
+		Expect(schema.Tables).To(HaveExactElements(And(
+			HaveField("Name", "foo"),
+			HaveField("Columns", HaveExactElements(
+				And(HaveField("Name", "bar"),
+					HaveField("Type", "TEXT"),
+					HaveField("NotNull", BeTrue()),
+					HaveField("Default", HaveValue(Equal("'baz'")))),
+				And(HaveField("Name", "id"),
+					HaveField("PrimaryKey", 1),
+					HaveField("Default", BeNil())),
+			)),
+			HaveField("Indices", HaveExactElements(And(
+				HaveField("Name", "foo_bar"),
+				HaveField("Unique", BeTrue()),
+				HaveField("Columns", Equal([]string{"bar", "id"}))))),
+		)))
+
+		fingerprint := Successful(db.SchemaFingerprint())
+		Expect(fingerprint.Hash).NotTo(Equal(testFixtureSchemaHash))
+		Expect(fingerprint).To(Equal(schema.Fingerprint()))
+		Expect(db.CheckSchema()).To(MatchError(ErrIncompatibleSchema))
+	})
+
+})
@@ -0,0 +1,143 @@
+// (c) Siemens AG 2026
+//
+// SPDX-License-Identifier: MIT
+
+package ieddata
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// SemVer is a parsed semantic app version, such as “1.9.18” or
+// “2.0.0-rc.1+build.5”.
+type SemVer struct {
+	Raw        string // unparsed version string.
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	PreRelease string // pre-release identifiers, such as “rc.1”, if any.
+	Build      string // build metadata, such as “build.5”, if any.
+}
+
+var semVerRe = regexp.MustCompile(
+	`^v?(\d+)(?:\.(\d+))?(?:\.(\d+))?(?:-([0-9A-Za-z.-]+))?(?:\+([0-9A-Za-z.-]+))?$`)
+
+// ParseSemVer parses a semantic version string, such as “1.9.18”. As app
+// versions aren't always strictly semantic versions, ParseSemVer accepts a
+// leading “v” and missing minor and patch numbers, which then default to zero.
+// It returns an error if the version string lacks at least a major version.
+func ParseSemVer(s string) (SemVer, error) {
+	m := semVerRe.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return SemVer{}, fmt.Errorf("invalid semantic version %q", s)
+	}
+	v := SemVer{Raw: s, PreRelease: m[4], Build: m[5]}
+	for idx, num := range []*uint64{&v.Major, &v.Minor, &v.Patch} {
+		if m[idx+1] == "" {
+			continue
+		}
+		var err error
+		if *num, err = strconv.ParseUint(m[idx+1], 10, 64); err != nil {
+			return SemVer{}, fmt.Errorf("invalid semantic version %q: %w", s, err)
+		}
+	}
+	return v, nil
+}
+
+// String returns the unparsed version string.
+func (v SemVer) String() string { return v.Raw }
+
+// Compare returns -1 if v is a lower version than w, +1 if v is higher than w,
+// and 0 if both versions have the same precedence. Following the semantic
+// versioning rules, pre-release versions have lower precedence than their
+// release versions and build metadata is ignored.
+func (v SemVer) Compare(w SemVer) int {
+	if c := cmp.Compare(v.Major, w.Major); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(v.Minor, w.Minor); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(v.Patch, w.Patch); c != 0 {
+		return c
+	}
+	switch {
+	case v.PreRelease == w.PreRelease:
+		return 0
+	case v.PreRelease == "":
+		return 1
+	case w.PreRelease == "":
+		return -1
+	}
+	return slices.CompareFunc(
+		strings.Split(v.PreRelease, "."), strings.Split(w.PreRelease, "."),
+		comparePreReleaseIdentifiers)
+}
+
+// Less returns true if v is a lower version than w.
+func (v SemVer) Less(w SemVer) bool { return v.Compare(w) < 0 }
+
+// comparePreReleaseIdentifiers compares two individual pre-release
+// identifiers: numeric identifiers compare numerically and have lower
+// precedence than alphanumeric identifiers, which compare lexically.
+func comparePreReleaseIdentifiers(a, b string) int {
+	anum, aerr := strconv.ParseUint(a, 10, 64)
+	bnum, berr := strconv.ParseUint(b, 10, 64)
+	switch {
+	case aerr == nil && berr == nil:
+		return cmp.Compare(anum, bnum)
+	case aerr == nil:
+		return -1
+	case berr == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// SemVer returns the parsed semantic version of the app version.
+func (a App) SemVer() (SemVer, error) {
+	return ParseSemVer(a.Version)
+}
+
+// AppsOlderThan returns the apps with versions lower than the specified
+// minimum versions, such as for checking compliance with required minimum app
+// versions. The minimum versions are keyed by either app ID or repository
+// name, with app IDs taking precedence. Apps without a minimum version are
+// skipped. Apps with versions that cannot be parsed are returned too, as they
+// cannot be shown to satisfy their minimum versions.
+//
+// If apps contains multiple versions of the same app, such as when returned by
+// Apps without the WithActiveVersions option, only the active version of each
+// app gets checked, so stale versions are never reported.
+//
+// AppsOlderThan returns an error if any of the minimum versions cannot be
+// parsed.
+func AppsOlderThan(apps []App, min map[string]string) ([]App, error) {
+	minVersions := make(map[string]SemVer, len(min))
+	for key, version := range min {
+		v, err := ParseSemVer(version)
+		if err != nil {
+			return nil, fmt.Errorf("invalid minimum version for %q: %w", key, err)
+		}
+		minVersions[key] = v
+	}
+	older := make([]App, 0)
+	for _, app := range activeVersions(apps) {
+		minVersion, ok := minVersions[app.Id]
+		if !ok {
+			if minVersion, ok = minVersions[app.RepositoryName]; !ok {
+				continue
+			}
+		}
+		version, err := app.SemVer()
+		if err != nil || version.Less(minVersion) {
+			older = append(older, app)
+		}
+	}
+	return older, nil
+}
@@ -0,0 +1,98 @@
+// (c) Siemens AG 2026
+//
+// SPDX-License-Identifier: MIT
+
+package ieddata
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/thediveo/success"
+)
+
+var _ = Describe("semantic app versions", func() {
+
+	DescribeTable("parsing versions",
+		func(s string, expected SemVer) {
+			expected.Raw = s
+			Expect(ParseSemVer(s)).To(Equal(expected))
+		},
+		Entry(nil, "1.9.18", SemVer{Major: 1, Minor: 9, Patch: 18}),
+		Entry(nil, "0.6.66666666666", SemVer{Major: 0, Minor: 6, Patch: 66666666666}),
+		Entry(nil, "v2.1", SemVer{Major: 2, Minor: 1}),
+		Entry(nil, "3", SemVer{Major: 3}),
+		Entry(nil, "2.0.0-rc.1+build.5", SemVer{Major: 2, PreRelease: "rc.1", Build: "build.5"}),
+	)
+
+	It("rejects invalid versions", func() {
+		Expect(ParseSemVer("")).Error().To(HaveOccurred())
+		Expect(ParseSemVer("1.2.x")).Error().To(MatchError(ContainSubstring(`invalid semantic version "1.2.x"`)))
+		Expect(ParseSemVer("99999999999999999999999")).Error().To(HaveOccurred())
+	})
+
+	It("compares versions", func() {
+		ordered := []string{
+			"0.6.66666666666",
+			"1.0.0-alpha",
+			"1.0.0-alpha.1",
+			"1.0.0-alpha.beta",
+			"1.0.0-beta.2",
+			"1.0.0-beta.11",
+			"1.0.0",
+			"1.9.18",
+			"1.10.0",
+		}
+		for idx := 1; idx < len(ordered); idx++ {
+			lower := Successful(ParseSemVer(ordered[idx-1]))
+			higher := Successful(ParseSemVer(ordered[idx]))
+			Expect(lower.Less(higher)).To(BeTrue(), "%s < %s", lower, higher)
+			Expect(higher.Compare(lower)).To(Equal(1), "%s > %s", higher, lower)
+		}
+		Expect(Successful(ParseSemVer("1.0.0+foo")).Compare(Successful(ParseSemVer("v1.0")))).To(BeZero())
+	})
+
+	It("finds apps older than required", func(ctx context.Context) {
+		db := Successful(OpenFileContext(ctx, "tests/sqlite-alpine-appengine-db/test-apps-and-device.db"))
+		defer func() { _ = db.Close() }()
+		apps := Successful(db.AppsContext(ctx, WithActiveVersions()))
+
+		appA := Successful(db.AppByIDContext(ctx, appAId))
+		Expect(appA.SemVer()).To(And(
+			HaveField("Major", uint64(1)),
+			HaveField("Minor", uint64(9)),
+			HaveField("Patch", uint64(18))))
+
+		older := Successful(AppsOlderThan(apps, map[string]string{
+			appAId: "1.10.0", // AppA, 1.9.18
+			"aaa":  "0.1.0",  // app ID takes precedence
+			"bbb":  "0.6.66666666666",
+			"ccc":  "1.1.1",
+		}))
+		Expect(older).To(ConsistOf(
+			HaveField("Title", "AppA"),
+			HaveField("Title", "AppC"),
+		))
+
+		Expect(AppsOlderThan(Successful(db.AppsContext(ctx)), map[string]string{
+			appAId: "1.10.0",
+			"ccc":  "1.1.1",
+		})).To(ConsistOf(
+			HaveField("Title", "AppA"),
+			HaveField("Title", "AppC"),
+		))
+
+		stale := App{Id: "x", Version: "0.9", VersionCreated: time.Unix(1, 0)}
+		active := App{Id: "x", Version: "1.2", VersionStatus: versionStatusInstalled}
+		Expect(AppsOlderThan([]App{stale, active}, map[string]string{"x": "1.0"})).To(BeEmpty())
+		Expect(AppsOlderThan([]App{active, stale}, map[string]string{"x": "1.0"})).To(BeEmpty())
+
+		Expect(AppsOlderThan([]App{{Id: "x", Version: "foobar"}}, map[string]string{"x": "1.0"})).
+			To(HaveExactElements(HaveField("Id", "x")))
+		Expect(AppsOlderThan(apps, map[string]string{"aaa": "foobar"})).Error().
+			To(MatchError(ContainSubstring(`invalid minimum version for "aaa"`)))
+	})
+
+})
@@ -0,0 +1,275 @@
+// (c) Siemens AG 2026
+//
+// SPDX-License-Identifier: MIT
+
+package ieddata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// sideFileSuffixes lists the suffixes of the SQLite side files that might hold
+// committed transactions not yet transferred into the main database file: the
+// write-ahead log as well as the rollback journal. The “-shm” shared memory
+// file isn't needed, as SQLite rebuilds it from the write-ahead log.
+var sideFileSuffixes = []string{"-wal", "-journal"}
+
+// maxSnapshotAttempts is the maximum number of attempts to copy a database
+// while it isn't changing.
+const maxSnapshotAttempts = 3
+
+// fileState describes the size and modification time of a database file or
+// one of its side files; the zero value represents a non-existing file.
+type fileState struct {
+	size    int64
+	modTime time.Time
+}
+
+// sourceState describes the main database file, followed by its side files in
+// the order of sideFileSuffixes.
+type sourceState []fileState
+
+// equal returns true if both source states describe the same file sizes and
+// modification times. Modification times are compared using time.Time.Equal,
+// as they might differ in their locations and monotonic clock readings.
+func (s sourceState) equal(other sourceState) bool {
+	return slices.EqualFunc(s, other, func(a, b fileState) bool {
+		return a.size == b.size && a.modTime.Equal(b.modTime)
+	})
+}
+
+// statSource returns the state of the specified database file and its side
+// files.
+func statSource(dbpath string) (sourceState, error) {
+	state := make(sourceState, 0, 1+len(sideFileSuffixes))
+	for _, name := range append([]string{dbpath}, sideFilePaths(dbpath)...) {
+		info, err := os.Stat(name)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && name != dbpath {
+				state = append(state, fileState{})
+				continue
+			}
+			return nil, err
+		}
+		state = append(state, fileState{size: info.Size(), modTime: info.ModTime()})
+	}
+	return state, nil
+}
+
+// sideFilePaths returns the paths of the side files of the specified database
+// file, in the order of sideFileSuffixes.
+func sideFilePaths(dbpath string) []string {
+	paths := make([]string, 0, len(sideFileSuffixes))
+	for _, suffix := range sideFileSuffixes {
+		paths = append(paths, dbpath+suffix)
+	}
+	return paths
+}
+
+// snapshot is a temporary copy of an SQLite database, including its side
+// files, inside a temporary directory of its own.
+type snapshot struct {
+	dir    string      // temporary directory containing the copies.
+	dbpath string      // path of the copied main database file.
+	source sourceState // state of the source database at the time of copying.
+}
+
+// takeSnapshot copies the specified SQLite database file together with its
+// side files into a new temporary directory that gets created inside tempDir
+// (or the default temporary directory if tempDir is empty). As the database
+// might be written to while copying it, takeSnapshot retries copying until
+// the database didn't change while being copied, but at most
+// maxSnapshotAttempts times; if the database kept changing, takeSnapshot
+// returns an error wrapping ErrDatabaseBusy. Copying stops early when the
+// specified context is done.
+func takeSnapshot(ctx context.Context, srcpath string, tempDir string) (*snapshot, error) {
+	origdbf, err := os.Open(srcpath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			err = fmt.Errorf("%w: %w", ErrDatabaseNotFound, err)
+		}
+		return nil, fmt.Errorf("unable to open database, reason: %w", err)
+	}
+	err = checkHeader(origdbf)
+	_ = origdbf.Close()
+	if err != nil {
+		return nil, fmt.Errorf("unable to open database, reason: %w", err)
+	}
+
+	snap, err := newSnapshot(tempDir, filepath.Base(srcpath))
+	if err != nil {
+		return nil, fmt.Errorf("unable to open database, reason: %w", err)
+	}
+	if err := snap.copyStable(ctx, srcpath, statSource); err != nil {
+		snap.remove()
+		return nil, fmt.Errorf("unable to open database, reason: %w", err)
+	}
+	return snap, nil
+}
+
+// copyStable copies the specified database file and any side files into the
+// snapshot directory until the source states as returned by the stat function
+// before and after copying are the same, but at most maxSnapshotAttempts
+// times. It returns an error wrapping ErrDatabaseBusy if the source changed
+// during each attempt, as the copy then might be torn.
+func (s *snapshot) copyStable(ctx context.Context, srcpath string, stat func(string) (sourceState, error)) error {
+	for range maxSnapshotAttempts {
+		before, err := stat(srcpath)
+		if err != nil {
+			return err
+		}
+		if err := s.copyFrom(ctx, srcpath); err != nil {
+			return err
+		}
+		s.source, err = stat(srcpath)
+		if err != nil {
+			return err
+		}
+		if before.equal(s.source) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: database kept changing during %d copy attempts",
+		ErrDatabaseBusy, maxSnapshotAttempts)
+}
+
+// newSnapshot returns a new and yet empty snapshot with its own temporary
+// directory inside tempDir (or the default temporary directory if tempDir is
+// empty). The snapshot's main database file will use the specified base name.
+func newSnapshot(tempDir string, basename string) (*snapshot, error) {
+	dir, err := os.MkdirTemp(tempDir, "temp-db-copy-*")
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot{
+		dir:    dir,
+		dbpath: filepath.Join(dir, basename),
+	}, nil
+}
+
+// copyFrom copies the specified database file and any side files into the
+// snapshot directory, removing any stale side file copies.
+func (s *snapshot) copyFrom(ctx context.Context, srcpath string) error {
+	if err := copyFile(ctx, s.dbpath, srcpath); err != nil {
+		return err
+	}
+	srcSideFiles := sideFilePaths(srcpath)
+	for idx, dst := range sideFilePaths(s.dbpath) {
+		err := copyFile(ctx, dst, srcSideFiles[idx])
+		if errors.Is(err, fs.ErrNotExist) {
+			_ = os.Remove(dst)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyFile copies the contents of the src file into the dst file, creating or
+// truncating dst as necessary. It returns an error wrapping fs.ErrNotExist if
+// the src file does not exist.
+func copyFile(ctx context.Context, dst, src string) error {
+	srcf, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = srcf.Close() }()
+	dstf, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(dstf, &contextReader{ctx: ctx, r: srcf})
+	if closeErr := dstf.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// open the snapshot copy of the database, verifying its integrity. The
+// returned database uses the FirstLower mapper.
+func (s *snapshot) open(ctx context.Context) (*sqlx.DB, error) {
+	// As sql.Open might just "validate its parameters" and this might mean near
+	// to nothing, we explicitly ping the database in order to see that it is
+	// okay.
+	db, err := sqlx.Open(dbDriverName, s.dbpath)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	// Opening the copy will have replayed any write-ahead log or rolled back
+	// any hot journal, so now check that we got a consistent copy.
+	var result string
+	if err := db.QueryRowContext(ctx, "PRAGMA integrity_check").Scan(&result); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("%w: %w", ErrInconsistentSnapshot, err)
+	}
+	if result != "ok" {
+		_ = db.Close()
+		return nil, fmt.Errorf("%w: integrity check failed: %s", ErrInconsistentSnapshot, result)
+	}
+
+	// Install a default mapper function that preserves camelCase, with the
+	// first letter always being lowercase.
+	db.MapperFunc(FirstLower)
+	return db, nil
+}
+
+// verify that the snapshot copy of the database can be opened and passes the
+// integrity check.
+func (s *snapshot) verify(ctx context.Context) error {
+	db, err := s.open(ctx)
+	if err != nil {
+		return err
+	}
+	return db.Close()
+}
+
+// remove the snapshot's temporary directory and all copies therein.
+func (s *snapshot) remove() {
+	_ = os.RemoveAll(s.dir)
+}
+
+// checkHeader checks that the specified file is an SQLite 3 database by looking
+// at its header, returning an error wrapping ErrNotADatabase otherwise. On
+// success, the file is rewound to its beginning.
+func checkHeader(f *os.File) error {
+	header := make([]byte, len(sqliteHeader))
+	if _, err := io.ReadFull(f, header); err != nil {
+		return fmt.Errorf("%w: %w", ErrNotADatabase, err)
+	}
+	if string(header) != sqliteHeader {
+		return fmt.Errorf("%w: invalid header", ErrNotADatabase)
+	}
+	_, err := f.Seek(0, io.SeekStart)
+	return err
+}
+
+// contextReader wraps an io.Reader so that reading stops with the context's
+// error as soon as the context is done.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+// Read reads from the wrapped reader, unless the context is already done.
+func (r *contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
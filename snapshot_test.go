@@ -0,0 +1,112 @@
+// (c) Siemens AG 2026
+//
+// SPDX-License-Identifier: MIT
+
+package ieddata
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/thediveo/success"
+)
+
+// newTestDB creates a new SQLite database in the specified directory with a
+// single table “foo” with the specified number of rows, returning the still
+// open database.
+func newTestDB(dir string, rows int, pragmas ...string) *sqlx.DB {
+	GinkgoHelper()
+	db := Successful(sqlx.Open(dbDriverName, filepath.Join(dir, "test.db")))
+	db.SetMaxOpenConns(1)
+	for _, pragma := range pragmas {
+		Expect(db.Exec("PRAGMA " + pragma)).Error().NotTo(HaveOccurred())
+	}
+	Expect(db.Exec("CREATE TABLE foo (bar TEXT)")).Error().NotTo(HaveOccurred())
+	for range rows {
+		Expect(db.Exec("INSERT INTO foo VALUES (?)", strings.Repeat("x", 1000))).Error().NotTo(HaveOccurred())
+	}
+	return db
+}
+
+var _ = Describe("database snapshots", func() {
+
+	It("includes the write-ahead log", func(ctx context.Context) {
+		srcdir := GinkgoT().TempDir()
+		srcdb := newTestDB(srcdir, 42, "journal_mode=WAL", "wal_autocheckpoint=0")
+		defer func() { _ = srcdb.Close() }()
+		Expect(filepath.Join(srcdir, "test.db-wal")).To(BeARegularFile())
+
+		snap := Successful(takeSnapshot(ctx, filepath.Join(srcdir, "test.db"), GinkgoT().TempDir()))
+		defer snap.remove()
+		Expect(snap.dbpath + "-wal").To(BeARegularFile())
+		Expect(snap.source).To(HaveLen(1 + len(sideFileSuffixes)))
+
+		db := Successful(snap.open(ctx))
+		defer func() { _ = db.Close() }()
+		var count int
+		Expect(db.QueryRowContext(ctx, "SELECT COUNT(*) FROM foo").Scan(&count)).To(Succeed())
+		Expect(count).To(Equal(42))
+	})
+
+	It("rejects inconsistent copies", func(ctx context.Context) {
+		srcdir := GinkgoT().TempDir()
+		srcdb := newTestDB(srcdir, 100)
+		Expect(srcdb.Close()).To(Succeed())
+
+		// Scribble over the database's pages following the first page, leaving
+		// the header intact.
+		f := Successful(os.OpenFile(filepath.Join(srcdir, "test.db"), os.O_WRONLY, 0))
+		Expect(f.WriteAt([]byte(strings.Repeat("\xff", 16384)), 4096)).Error().NotTo(HaveOccurred())
+		Expect(f.Close()).To(Succeed())
+
+		snap := Successful(takeSnapshot(ctx, filepath.Join(srcdir, "test.db"), GinkgoT().TempDir()))
+		defer snap.remove()
+		Expect(snap.open(ctx)).Error().To(MatchError(ErrInconsistentSnapshot))
+	})
+
+	It("gives up on continuously changing databases", func(ctx context.Context) {
+		srcdir := GinkgoT().TempDir()
+		Expect(newTestDB(srcdir, 1).Close()).To(Succeed())
+
+		snap := Successful(newSnapshot(GinkgoT().TempDir(), "test.db"))
+		defer snap.remove()
+		size := int64(0)
+		Expect(snap.copyStable(ctx, filepath.Join(srcdir, "test.db"), func(string) (sourceState, error) {
+			size++
+			return sourceState{{size: size}}, nil
+		})).To(MatchError(ErrDatabaseBusy))
+		Expect(size).To(Equal(int64(2 * maxSnapshotAttempts)))
+
+		Expect(snap.copyStable(ctx, filepath.Join(srcdir, "test.db"), func(string) (sourceState, error) {
+			size++
+			return sourceState{{size: min(size, 2*maxSnapshotAttempts+3)}}, nil
+		})).To(Succeed())
+	})
+
+	It("compares source states by their modification instants", func() {
+		now := time.Now()
+		state := sourceState{{size: 42, modTime: now}, {}}
+		Expect(state.equal(sourceState{{size: 42, modTime: now.Round(0).In(time.FixedZone("X", 3600))}, {}})).
+			To(BeTrue())
+		Expect(state.equal(sourceState{{size: 42, modTime: now.Add(time.Nanosecond)}, {}})).To(BeFalse())
+		Expect(state.equal(sourceState{{size: 43, modTime: now}, {}})).To(BeFalse())
+		Expect(state.equal(sourceState{{size: 42, modTime: now}})).To(BeFalse())
+	})
+
+	It("removes the snapshot", func(ctx context.Context) {
+		srcdir := GinkgoT().TempDir()
+		Expect(newTestDB(srcdir, 1).Close()).To(Succeed())
+		snap := Successful(takeSnapshot(ctx, filepath.Join(srcdir, "test.db"), ""))
+		Expect(snap.dbpath).To(BeARegularFile())
+		snap.remove()
+		Expect(snap.dir).NotTo(BeAnExistingFile())
+	})
+
+})
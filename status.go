@@ -0,0 +1,74 @@
+// (c) Siemens AG 2026
+//
+// SPDX-License-Identifier: MIT
+
+package ieddata
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+)
+
+// versionStatusInstalled is assumed to be the versionStatus of the currently
+// installed app version. The IE runtime doesn't document its app and version
+// status values, so App and AppVersion keep them as plain numbers. This
+// assumption is based solely on the test database, where all app versions
+// have this status; it has not yet been confirmed with the databases of
+// actual IE runtimes, so it must not become part of the API.
+const versionStatusInstalled = 4
+
+// Flag is a boolean stored as a (tiny) integer in the app engine database,
+// such as the isVisible column of the application table.
+type Flag bool
+
+// String returns “true” or “false”.
+func (f Flag) String() string {
+	return strconv.FormatBool(bool(f))
+}
+
+// MarshalText returns “true” or “false”.
+func (f Flag) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText sets the flag from “true”, “false”, “1”, “0”, and the other
+// boolean representations accepted by strconv.ParseBool.
+func (f *Flag) UnmarshalText(text []byte) error {
+	b, err := strconv.ParseBool(string(text))
+	if err != nil {
+		return fmt.Errorf("invalid flag %q", text)
+	}
+	*f = Flag(b)
+	return nil
+}
+
+// Scan implements the sql.Scanner interface, accepting integers (with any
+// non-zero value being true), booleans, and their textual representations. A
+// NULL value is false.
+func (f *Flag) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*f = false
+	case int64:
+		*f = v != 0
+	case bool:
+		*f = Flag(v)
+	case []byte:
+		return f.UnmarshalText(v)
+	case string:
+		return f.UnmarshalText([]byte(v))
+	default:
+		return fmt.Errorf("unsupported flag value of type %T", src)
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface, returning 1 for true and 0 for
+// false, as stored in the app engine database.
+func (f Flag) Value() (driver.Value, error) {
+	if f {
+		return int64(1), nil
+	}
+	return int64(0), nil
+}
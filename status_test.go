@@ -0,0 +1,60 @@
+// (c) Siemens AG 2026
+//
+// SPDX-License-Identifier: MIT
+
+package ieddata
+
+import (
+	"context"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/thediveo/success"
+)
+
+var _ = Describe("app status and flags", func() {
+
+	It("marshals and unmarshals flags", func() {
+		type flags struct {
+			On  Flag
+			Off Flag
+		}
+		j := Successful(json.Marshal(flags{On: true}))
+		Expect(string(j)).To(MatchJSON(`{"On":"true","Off":"false"}`))
+
+		var f flags
+		Expect(json.Unmarshal(j, &f)).To(Succeed())
+		Expect(f).To(Equal(flags{On: true}))
+
+		Expect(json.Unmarshal([]byte(`{"On":"foo"}`), &f)).To(MatchError(ContainSubstring(`invalid flag "foo"`)))
+	})
+
+	It("scans flags", func() {
+		var f Flag
+		for _, src := range []any{int64(1), true, "true", []byte("1")} {
+			f = false
+			Expect(f.Scan(src)).To(Succeed())
+			Expect(f).To(Equal(Flag(true)), "%v", src)
+		}
+		for _, src := range []any{int64(0), false, "0", nil} {
+			f = true
+			Expect(f.Scan(src)).To(Succeed())
+			Expect(f).To(Equal(Flag(false)), "%v", src)
+		}
+		Expect(f.Scan(42.0)).To(MatchError(ContainSubstring("unsupported flag value")))
+		Expect(Flag(true).Value()).To(Equal(int64(1)))
+		Expect(Flag(false).Value()).To(Equal(int64(0)))
+	})
+
+	It("reads typed app information", func(ctx context.Context) {
+		db := Successful(OpenFileContext(ctx, "tests/sqlite-alpine-appengine-db/test-apps-and-device.db"))
+		defer func() { _ = db.Close() }()
+		Expect(db.AppsContext(ctx)).To(HaveEach(And(
+			HaveField("AppStatus", 0),
+			HaveField("VersionStatus", versionStatusInstalled),
+			HaveField("IsVisible", Flag(true)),
+		)))
+	})
+
+})
@@ -0,0 +1,94 @@
+// (c) Siemens AG 2026
+//
+// SPDX-License-Identifier: MIT
+
+package ieddata
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// AppVersion describes an individual version of an IE app, as stored in the
+// applicationversions table of the platformbox.db. Field names follow the
+// corresponding App fields.
+type AppVersion struct {
+	VersionId         string `db:"appVersionId"`
+	AppId             string `db:"appId"`
+	Version           string `db:"appVersion"`
+	VersionStatus     int
+	ReleaseNotes      string
+	ComposerFilepath  string `db:"composerFilePath"`
+	RedirectType      string
+	RedirectUrl       string
+	RESTRedirectUrl   string `db:"restRedirectUrl"`
+	RedirectSection   string
+	ToExecuteOrder    string
+	Metadata          string
+	Created           time.Time `db:"createdDate"`
+	Modified          time.Time `db:"modifiedDate"`
+	ServiceLabels     string
+	IsSecure          Flag
+	IsSwarmModeEnable Flag
+}
+
+// AppVersions returns all versions of the app with the specified app ID,
+// ordered by their creation dates, oldest first. If there are no versions for
+// the app ID, AppVersions returns an AppNotFoundError.
+func (db *AppEngineDB) AppVersions(appId string) ([]AppVersion, error) {
+	return db.AppVersionsContext(context.Background(), appId)
+}
+
+// AppVersionsContext works like AppVersions, but additionally honors
+// cancellation and deadlines of the specified context while querying the
+// database.
+func (db *AppEngineDB) AppVersionsContext(ctx context.Context, appId string) ([]AppVersion, error) {
+	// As the IE runtime stores creation dates sometimes as Unix epoch seconds
+	// and sometimes as date/time strings, SQLite's ordering would be off, so we
+	// need to sort the versions ourselves.
+	versions, err := ScanAllContext[AppVersion](ctx, db.DB,
+		"SELECT * FROM applicationversions WHERE appId=?", appId)
+	if err != nil {
+		return nil, wrongDatabaseError(err)
+	}
+	if len(versions) == 0 {
+		return nil, &AppNotFoundError{Column: "appId", Value: appId}
+	}
+	slices.SortStableFunc(versions, func(a, b AppVersion) int {
+		return a.Created.Compare(b.Created)
+	})
+	return versions, nil
+}
+
+// isPreferredVersion returns true if the candidate app version is to be
+// preferred as the active version over the current app version: an installed
+// version wins over a non-installed version, otherwise the more recently
+// created version wins.
+func isPreferredVersion(candidate, current *App) bool {
+	candidateInstalled := candidate.VersionStatus == versionStatusInstalled
+	currentInstalled := current.VersionStatus == versionStatusInstalled
+	if candidateInstalled != currentInstalled {
+		return candidateInstalled
+	}
+	return candidate.VersionCreated.After(current.VersionCreated)
+}
+
+// activeVersions returns only the active version of each app in the specified
+// apps, in the order the apps first appear.
+func activeVersions(apps []App) []App {
+	active := make([]App, 0, len(apps))
+	activeIndices := map[string]int{} // app ID to index into active
+	for _, app := range apps {
+		idx, ok := activeIndices[app.Id]
+		if !ok {
+			activeIndices[app.Id] = len(active)
+			active = append(active, app)
+			continue
+		}
+		if isPreferredVersion(&app, &active[idx]) {
+			active[idx] = app
+		}
+	}
+	return active
+}
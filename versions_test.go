@@ -0,0 +1,100 @@
+// (c) Siemens AG 2026
+//
+// SPDX-License-Identifier: MIT
+
+package ieddata
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/thediveo/success"
+)
+
+const (
+	appAId = "195ff5e2e15a149ca5eb7c59d3857cc5"
+	appBId = "7bd06d3bbf816d0658d5a871b0a498ff"
+)
+
+var _ = Describe("app versions", func() {
+
+	var dbpath string
+
+	BeforeEach(func() {
+		dbpath = copyTestAppsDB()
+		srcdb := Successful(sqlx.Open(dbDriverName, dbpath))
+		defer func() { _ = srcdb.Close() }()
+		Expect(srcdb.Exec(`INSERT INTO applicationversions
+			(appVersionId, appId, appVersion, versionStatus, releaseNotes, composerFilePath,
+			 redirectType, redirectUrl, restRedirectUrl, redirectSection, toExecuteOrder, metadata,
+			 createdDate, modifiedDate)
+			VALUES ('b2', ?, '0.7.0', 1, '', '', '', '', '', '', '', 'null', '2022-02-02', '2022-02-02')`,
+			appBId)).Error().NotTo(HaveOccurred())
+	})
+
+	It("returns the versions of an app in order", func(ctx context.Context) {
+		db := Successful(OpenFileContext(ctx, dbpath))
+		defer func() { _ = db.Close() }()
+
+		Expect(db.AppVersionsContext(ctx, appBId)).To(HaveExactElements(
+			And(HaveField("Version", "0.6.66666666666"),
+				HaveField("VersionStatus", versionStatusInstalled),
+				HaveField("Created", time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))),
+			And(HaveField("Version", "0.7.0"), HaveField("AppId", appBId)),
+		))
+		Expect(db.AppVersions(appAId)).To(HaveExactElements(
+			HaveField("Created", time.Unix(1751964294, 0).UTC())))
+		Expect(db.AppVersions("foobar")).Error().To(MatchError(ErrAppNotFound))
+	})
+
+	It("selects the active versions", func(ctx context.Context) {
+		db := Successful(OpenFileContext(ctx, dbpath))
+		defer func() { _ = db.Close() }()
+
+		Expect(db.Apps()).To(HaveLen(5))
+		apps := Successful(db.AppsContext(ctx, WithActiveVersions()))
+		Expect(apps).To(HaveLen(4))
+		Expect(apps).To(ContainElement(And(
+			HaveField("Id", appBId),
+			HaveField("Version", "0.6.66666666666"))))
+		Expect(db.AppByID(appBId)).To(HaveField("Version", "0.6.66666666666"))
+
+		count := 0
+		for range db.AppsSeq(ctx, WithActiveVersions()) {
+			count++
+			break
+		}
+		Expect(count).To(Equal(1))
+	})
+
+	It("selects the latest version when none is installed", func(ctx context.Context) {
+		srcdb := Successful(sqlx.Open(dbDriverName, dbpath))
+		Expect(srcdb.Exec("UPDATE applicationversions SET versionStatus=1 WHERE appId=?", appBId)).
+			Error().NotTo(HaveOccurred())
+		Expect(srcdb.Close()).To(Succeed())
+
+		db := Successful(OpenFileContext(ctx, dbpath))
+		defer func() { _ = db.Close() }()
+		Expect(db.AppByIDContext(ctx, appBId)).To(And(
+			HaveField("Version", "0.7.0"),
+			HaveField("VersionCreated", time.Date(2022, 2, 2, 0, 0, 0, 0, time.UTC))))
+	})
+
+	It("scans timestamps", func() {
+		var t time.Time
+		s := &timestampScanner{t: &t}
+		Expect(s.Scan(int64(1751964294))).To(Succeed())
+		Expect(t).To(Equal(time.Unix(1751964294, 0).UTC()))
+		Expect(s.Scan([]byte("2021-10-20 12:45:01"))).To(Succeed())
+		Expect(t).To(Equal(time.Date(2021, 10, 20, 12, 45, 1, 0, time.UTC)))
+		Expect(s.Scan(nil)).To(Succeed())
+		Expect(t).To(BeZero())
+		Expect(s.Scan("foobar")).To(MatchError(ContainSubstring("invalid date/time")))
+		Expect(s.Scan(42.0)).To(MatchError(ContainSubstring("unsupported date/time")))
+	})
+
+})
@@ -0,0 +1,165 @@
+// (c) Siemens AG 2026
+//
+// SPDX-License-Identifier: MIT
+
+package ieddata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultWatchInterval is the default interval in which Watch checks the app
+// engine database for changes.
+const DefaultWatchInterval = 5 * time.Second
+
+// AppEventType identifies the kind of change to an installed app.
+type AppEventType int
+
+// The kinds of changes to installed apps.
+const (
+	AppAdded          AppEventType = iota // app has been installed.
+	AppRemoved                            // app has been uninstalled.
+	AppVersionChanged                     // app has been upgraded or downgraded.
+	AppStatusChanged                      // app or app version status has changed.
+	AppWatchFailed                        // checking for changes failed, see AppEvent.Err.
+)
+
+var appEventTypeNames = map[AppEventType]string{
+	AppAdded:          "AppAdded",
+	AppRemoved:        "AppRemoved",
+	AppVersionChanged: "AppVersionChanged",
+	AppStatusChanged:  "AppStatusChanged",
+	AppWatchFailed:    "AppWatchFailed",
+}
+
+// String returns the name of the app event type.
+func (t AppEventType) String() string {
+	if name, ok := appEventTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("AppEventType(%d)", int(t))
+}
+
+// AppEvent describes a change to an installed app, with the app information
+// before and after the change. Before is nil for AppAdded, and After is nil for
+// AppRemoved. AppWatchFailed events carry only an error in Err and no app
+// information.
+type AppEvent struct {
+	Type   AppEventType
+	Before *App
+	After  *App
+	Err    error
+}
+
+// WatchOption configures Watch.
+type WatchOption func(*watchOptions)
+
+type watchOptions struct {
+	interval time.Duration
+}
+
+// WithWatchInterval specifies the interval in which Watch checks the app
+// engine database for changes; it defaults to DefaultWatchInterval.
+func WithWatchInterval(interval time.Duration) WatchOption {
+	return func(o *watchOptions) {
+		if interval > 0 {
+			o.interval = interval
+		}
+	}
+}
+
+// Watch watches the installed apps for changes, emitting AppEvent elements over
+// the returned channel. Watch checks the original app engine database for
+// changes in its size and modification time in regular intervals (see
+// WithWatchInterval), and only then refreshes the snapshot, as Refresh does.
+// Whenever the snapshot differs from the one Watch last looked at, regardless
+// of whether Watch itself, the caller, or another Watch refreshed it, Watch
+// compares the installed apps with their active versions (see
+// WithActiveVersions) before and after and emits AppAdded, AppRemoved,
+// AppVersionChanged, and AppStatusChanged events. If checking for changes
+// fails, Watch emits an AppWatchFailed event and then continues watching,
+// unless the database has been closed.
+//
+// Watch returns an error if it cannot determine the currently installed apps
+// to start with. Otherwise, watching continues until the specified context
+// gets cancelled, and then the returned channel is closed. Watching a database
+// without an original database file to refresh from never emits any events.
+func (db *AppEngineDB) Watch(ctx context.Context, opts ...WatchOption) (<-chan AppEvent, error) {
+	o := watchOptions{interval: DefaultWatchInterval}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	// Determine the snapshot before querying the apps: if a refresh sneaks in
+	// between, we merely query the apps once more without finding changes.
+	snap := db.currentSnapshot()
+	apps, err := db.AppsContext(ctx, WithActiveVersions())
+	if err != nil {
+		return nil, err
+	}
+	eventCh := make(chan AppEvent)
+	go func() {
+		defer close(eventCh)
+		ticker := time.NewTicker(o.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			var events []AppEvent
+			_, err := db.Refresh(ctx)
+			if newSnap := db.currentSnapshot(); err == nil && newSnap != snap {
+				var newApps []App
+				newApps, err = db.AppsContext(ctx, WithActiveVersions())
+				if err == nil {
+					events = appEvents(apps, newApps)
+					apps, snap = newApps, newSnap
+				}
+			}
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				events = []AppEvent{{Type: AppWatchFailed, Err: err}}
+			}
+			for _, event := range events {
+				select {
+				case eventCh <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if errors.Is(err, ErrClosed) {
+				return
+			}
+		}
+	}()
+	return eventCh, nil
+}
+
+// appEvents returns the events describing the changes between the before and
+// after apps.
+func appEvents(before, after []App) []AppEvent {
+	var events []AppEvent
+	diff := DiffApps(before, after)
+	for idx := range diff.Added {
+		events = append(events, AppEvent{Type: AppAdded, After: &diff.Added[idx]})
+	}
+	for idx := range diff.Removed {
+		events = append(events, AppEvent{Type: AppRemoved, Before: &diff.Removed[idx]})
+	}
+	for idx := range diff.Changed {
+		change := &diff.Changed[idx]
+		if change.Has("Version", "VersionId") {
+			events = append(events, AppEvent{Type: AppVersionChanged, Before: &change.Before, After: &change.After})
+		}
+		if change.Has("AppStatus", "VersionStatus") {
+			events = append(events, AppEvent{Type: AppStatusChanged, Before: &change.Before, After: &change.After})
+		}
+	}
+	return events
+}
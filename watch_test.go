@@ -0,0 +1,113 @@
+// (c) Siemens AG 2026
+//
+// SPDX-License-Identifier: MIT
+
+package ieddata
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/thediveo/lxkns/model"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/thediveo/success"
+)
+
+// copyTestAppsDB copies the test apps and device database into a new temporary
+// directory, returning the path of the copy.
+func copyTestAppsDB() string {
+	GinkgoHelper()
+	dbpath := filepath.Join(GinkgoT().TempDir(), PlatformBoxDb)
+	Expect(os.WriteFile(dbpath,
+		Successful(os.ReadFile("tests/sqlite-alpine-appengine-db/test-apps-and-device.db")),
+		0600)).To(Succeed())
+	return dbpath
+}
+
+var _ = Describe("watching apps", func() {
+
+	It("emits app events", func(ctx context.Context) {
+		dbpath := copyTestAppsDB()
+		db := Successful(open(ctx, dbpath, model.PIDType(os.Getpid())))
+		defer func() { _ = db.Close() }()
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		events := Successful(db.Watch(ctx, WithWatchInterval(50*time.Millisecond)))
+
+		srcdb := Successful(sqlx.Open(dbDriverName, dbpath))
+		defer func() { _ = srcdb.Close() }()
+		Expect(srcdb.Exec("UPDATE applicationversions SET appVersion='1.9.19' WHERE appId='195ff5e2e15a149ca5eb7c59d3857cc5'")).
+			Error().NotTo(HaveOccurred())
+		Expect(srcdb.Exec("UPDATE application SET appStatus=1 WHERE appId='7bd06d3bbf816d0658d5a871b0a498ff'")).
+			Error().NotTo(HaveOccurred())
+		Expect(srcdb.Exec("DELETE FROM application WHERE appId='1842f53281412f9c657c7765494ff80e'")).
+			Error().NotTo(HaveOccurred())
+
+		var received []AppEvent
+		Eventually(func() []AppEvent {
+			select {
+			case event := <-events:
+				received = append(received, event)
+			default:
+			}
+			return received
+		}).Within(5 * time.Second).ProbeEvery(10 * time.Millisecond).Should(ConsistOf(
+			And(HaveField("Type", AppVersionChanged),
+				HaveField("Before.Version", "1.9.18"),
+				HaveField("After.Version", "1.9.19")),
+			And(HaveField("Type", AppStatusChanged),
+				HaveField("Before.AppStatus", 0),
+				HaveField("After.AppStatus", 1)),
+			And(HaveField("Type", AppRemoved),
+				HaveField("Before.Title", "AppC"),
+				HaveField("After", BeNil())),
+		))
+
+		cancel()
+		Eventually(events).Within(2 * time.Second).Should(BeClosed())
+	})
+
+	It("emits app events also after refreshes by others", func(ctx context.Context) {
+		dbpath := copyTestAppsDB()
+		db := Successful(open(ctx, dbpath, model.PIDType(os.Getpid())))
+		defer func() { _ = db.Close() }()
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		events := Successful(db.Watch(ctx, WithWatchInterval(50*time.Millisecond)))
+		otherEvents := Successful(db.Watch(ctx, WithWatchInterval(50*time.Millisecond)))
+
+		srcdb := Successful(sqlx.Open(dbDriverName, dbpath))
+		defer func() { _ = srcdb.Close() }()
+		Expect(srcdb.Exec("DELETE FROM application WHERE appId='1842f53281412f9c657c7765494ff80e'")).
+			Error().NotTo(HaveOccurred())
+		Expect(db.Refresh(ctx)).To(BeTrue())
+
+		for _, ch := range []<-chan AppEvent{events, otherEvents} {
+			Eventually(ch).Within(5 * time.Second).Should(Receive(And(
+				HaveField("Type", AppRemoved),
+				HaveField("Before.Title", "AppC"))))
+		}
+		Consistently(events).Within(250 * time.Millisecond).ShouldNot(Receive())
+	})
+
+	It("returns app events", func() {
+		before := []App{{Id: "a", Version: "1"}, {Id: "b"}, {Id: "b"}, {Id: "c", AppStatus: 1}}
+		after := []App{{Id: "a", Version: "2"}, {Id: "c", AppStatus: 2}, {Id: "d"}, {Id: "d"}}
+		Expect(appEvents(before, after)).To(ConsistOf(
+			And(HaveField("Type", AppAdded), HaveField("After.Id", "d")),
+			And(HaveField("Type", AppRemoved), HaveField("Before.Id", "b")),
+			And(HaveField("Type", AppVersionChanged), HaveField("After.Id", "a")),
+			And(HaveField("Type", AppStatusChanged), HaveField("After.Id", "c")),
+		))
+		Expect(AppAdded.String()).To(Equal("AppAdded"))
+		Expect(AppEventType(42).String()).To(Equal("AppEventType(42)"))
+	})
+
+})